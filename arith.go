@@ -0,0 +1,89 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// uint128 is an unsigned 128-bit integer used to accumulate
+// amount × price products without overflow.
+type uint128 struct {
+	hi uint64
+	lo uint64
+}
+
+// mul64 returns the full 128-bit product of a and b.
+func mul64(a, b uint64) uint128 {
+	hi, lo := bits.Mul64(a, b)
+	return uint128{hi: hi, lo: lo}
+}
+
+// add returns u + v, wrapping around on 128-bit overflow.
+func (u uint128) add(v uint128) uint128 {
+	lo, carry := bits.Add64(u.lo, v.lo, 0)
+	hi, _ := bits.Add64(u.hi, v.hi, carry)
+	return uint128{hi: hi, lo: lo}
+}
+
+// cmp returns -1, 0 or +1 depending on whether u is less than, equal to or greater than v.
+func (u uint128) cmp(v uint128) int {
+	if c := cmp.Compare(u.hi, v.hi); c != 0 {
+		return c
+	}
+	return cmp.Compare(u.lo, v.lo)
+}
+
+// saturated returns u as uint64, clamped to math.MaxUint64 if it does not fit.
+func (u uint128) saturated() uint64 {
+	if u.hi != 0 {
+		return math.MaxUint64
+	}
+	return u.lo
+}
+
+// float64 returns u converted to the nearest float64 value.
+func (u uint128) float64() float64 {
+	return float64(u.hi)*(1<<64) + float64(u.lo)
+}
+
+// String returns u in decimal.
+func (u uint128) String() string {
+	value := new(big.Int).SetUint64(u.hi)
+	value.Lsh(value, 64)
+	return value.Or(value, new(big.Int).SetUint64(u.lo)).String()
+}
+
+// div64 returns u / d truncated toward zero.
+// The quotient must fit uint64, which holds for averages like value / amount.
+func (u uint128) div64(d uint64) uint64 {
+	quotient, _ := bits.Div64(u.hi, u.lo, d)
+	return quotient
+}
+
+// divRound64 returns u / d rounded half up.
+// Like div64, the quotient must fit uint64.
+func (u uint128) divRound64(d uint64) uint64 {
+	quotient, remainder := bits.Div64(u.hi, u.lo, d)
+	if remainder >= d-remainder {
+		quotient++
+	}
+	return quotient
+}
+
+// signedDiff returns a - b as int64, clamped to the int64 range.
+func signedDiff(a, b uint64) int64 {
+	if a >= b {
+		return int64(min(a-b, math.MaxInt64))
+	}
+	return -int64(min(b-a-1, math.MaxInt64)) - 1
+}
+
+// mid returns the value halfway between a and b rounded down without overflowing.
+func mid(a, b uint64) uint64 {
+	return a/2 + b/2 + a&b&1
+}
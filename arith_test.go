@@ -0,0 +1,83 @@
+package orderstracker
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_uint128(t *testing.T) {
+	got := mul64(math.MaxUint64, 2).add(mul64(1, 2))
+	if got.hi != 2 || got.lo != 0 {
+		t.Errorf("Unexpected product sum: %+v", got)
+	}
+	if got.saturated() != math.MaxUint64 {
+		t.Error("Should saturate values exceeding uint64")
+	}
+	if mul64(3, 4).saturated() != 12 {
+		t.Error("Should keep values fitting uint64")
+	}
+	if got := mul64(math.MaxUint64, 7).div64(7); got != math.MaxUint64 {
+		t.Errorf("Unexpected quotient: %v", got)
+	}
+	if got.float64() != 2*(1<<64) {
+		t.Errorf("Unexpected float conversion: %v", got.float64())
+	}
+	if got.String() != "36893488147419103232" || (uint128{}).String() != "0" {
+		t.Errorf("Unexpected decimal: %v", got)
+	}
+}
+
+func Test_signedDiff(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int64
+	}{
+		{10, 3, 7},
+		{3, 10, -7},
+		{math.MaxUint64, 0, math.MaxInt64},
+		{0, math.MaxUint64, math.MinInt64},
+		{0, 1 << 63, math.MinInt64},
+	}
+	for _, test := range tests {
+		if got := signedDiff(test.a, test.b); got != test.want {
+			t.Errorf("signedDiff(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func Test_mid(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want uint64
+	}{
+		{99, 101, 100},
+		{100, 101, 100},
+		{101, 101, 101},
+		{math.MaxUint64, math.MaxUint64, math.MaxUint64},
+		{math.MaxUint64 - 1, math.MaxUint64, math.MaxUint64 - 1},
+	}
+	for _, test := range tests {
+		if got := mid(test.a, test.b); got != test.want {
+			t.Errorf("mid(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func Test_divRound64(t *testing.T) {
+	tests := []struct {
+		u    uint128
+		d    uint64
+		want uint64
+	}{
+		{uint128{lo: 5}, 2, 3},
+		{uint128{lo: 7}, 3, 2},
+		{uint128{lo: 8}, 3, 3},
+		{uint128{lo: 9}, 3, 3},
+		{mul64(math.MaxUint64, 4).add(uint128{lo: 1}), 4, math.MaxUint64},
+	}
+	for _, test := range tests {
+		if got := test.u.divRound64(test.d); got != test.want {
+			t.Errorf("%+v.divRound64(%v) = %v, want %v", test.u, test.d, got, test.want)
+		}
+	}
+}
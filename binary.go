@@ -0,0 +1,359 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// binaryVersion is the first byte of the binary snapshot, it changes with the encoding.
+const binaryVersion = 5
+
+// MarshalBinary encodes the state of all tracked orders, including their reports, fills and
+// transition history, in a compact binary form for fast checkpointing. Market data and
+// configuration are not encoded. Orders are encoded sorted by client ID, so trackers
+// in the same state produce identical data.
+func (t *Tracker) MarshalBinary() ([]byte, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	clids := slices.Sorted(maps.Keys(t.orders))
+	data := []byte{binaryVersion}
+	data = binary.AppendUvarint(data, uint64(len(clids)))
+	var err error
+	for _, clid := range clids {
+		if data, err = t.orders[clid].appendBinary(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the tracked orders with the ones encoded by MarshalBinary.
+// Market data, configuration and statistics are kept, the restored orders are not recorded
+// in the event log. Returns ErrInvalidSnapshot if the data is malformed, in which case
+// the tracked orders are not changed, and ErrTrackerClosed after Close.
+func (t *Tracker) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return fmt.Errorf("%w: unsupported version", ErrInvalidSnapshot)
+	}
+	d := binaryDecoder{data: data[1:]}
+	count := d.uvarint()
+	// Every order takes more than one byte, so a larger count is malformed
+	if count > uint64(len(d.data)) {
+		return fmt.Errorf("%w: %d orders in %d bytes", ErrInvalidSnapshot, count, len(d.data))
+	}
+	orders := make(map[OrderClientID]*orderContext, count)
+	for range count {
+		orderContext := d.orderContext()
+		if d.err != nil {
+			break
+		}
+		orders[orderContext.Order.ClientID] = orderContext
+	}
+	if d.err == nil && len(d.data) != 0 {
+		d.fail("%d trailing bytes", len(d.data))
+	}
+	if d.err != nil {
+		return d.err
+	}
+
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	if err := t.open(); err != nil {
+		return err
+	}
+	for _, exchange := range t.exchanges {
+		for symbolID, symbolContext := range exchange {
+			symbolContext.orders = nil
+			exchange[symbolID] = symbolContext
+		}
+	}
+	t.orders = orders
+	for _, orderContext := range orders {
+		exchange := t.exchanges[orderContext.Order.Exchange]
+		if exchange == nil {
+			exchange = make(map[SymbolID]marketData)
+			t.exchanges[orderContext.Order.Exchange] = exchange
+		}
+		symbolContext := exchange[orderContext.Order.Symbol]
+		symbolContext.add(orderContext, t.orderCapacity)
+		exchange[orderContext.Order.Symbol] = symbolContext
+	}
+	return nil
+}
+
+// appendBinary appends the binary encoding of the order state to the data.
+func (c *orderContext) appendBinary(data []byte) ([]byte, error) {
+	var err error
+	data = binary.AppendUvarint(data, uint64(c.Status))
+	if data, err = appendOrder(data, &c.Order); err != nil {
+		return nil, err
+	}
+	if data, err = appendOrder(data, &c.OriginalOrder); err != nil {
+		return nil, err
+	}
+	data = binary.AppendUvarint(data, uint64(c.LastReport.Kind))
+	for _, tm := range []time.Time{c.LastReport.Time, c.StatusSince, c.PlacingTime, c.PlacedTime} {
+		if data, err = tm.AppendBinary(data); err != nil {
+			return nil, err
+		}
+	}
+	data = appendString(data, c.LastReport.Message)
+	data = binary.AppendUvarint(data, c.LastReport.Amount)
+	data = binary.AppendUvarint(data, uint64(c.LastReport.Price))
+	data = binary.AppendVarint(data, c.LastReport.Fee)
+	data = binary.AppendUvarint(data, uint64(c.LastReport.RejectCode))
+
+	data = binary.AppendUvarint(data, uint64(len(c.Fills)))
+	for _, fill := range c.Fills {
+		if data, err = fill.Time.AppendBinary(data); err != nil {
+			return nil, err
+		}
+		data = appendString(data, fill.TradeID)
+		data = binary.AppendUvarint(data, fill.Amount)
+		data = binary.AppendUvarint(data, uint64(fill.Price))
+		data = binary.AppendVarint(data, fill.Fee)
+	}
+	data = binary.AppendUvarint(data, uint64(len(c.TradeIDs)))
+	for _, tradeID := range slices.Sorted(maps.Keys(c.TradeIDs)) {
+		data = appendString(data, tradeID)
+	}
+	data = binary.AppendUvarint(data, uint64(len(c.History)))
+	for _, transition := range c.History {
+		if data, err = transition.Time.AppendBinary(data); err != nil {
+			return nil, err
+		}
+		data = binary.AppendUvarint(data, uint64(transition.From))
+		data = binary.AppendUvarint(data, uint64(transition.To))
+		data = binary.AppendUvarint(data, uint64(transition.Report))
+		data = binary.AppendUvarint(data, uint64(transition.Price))
+		data = binary.AppendUvarint(data, transition.Amount)
+	}
+
+	data = binary.AppendVarint(data, c.Fees)
+	data = appendBool(data, c.MovePending)
+	data = binary.AppendUvarint(data, uint64(c.Trimmed.Count))
+	if data, err = c.Trimmed.FirstTime.AppendBinary(data); err != nil {
+		return nil, err
+	}
+	data = appendUint128(data, c.Improvement)
+	data = binary.AppendUvarint(data, c.FilledAmount)
+	data = appendUint128(data, c.FilledValue)
+	return appendUint128(data, c.ReportValue), nil
+}
+
+// appendOrder appends the binary encoding of the order to the data.
+func appendOrder(data []byte, order *Order) ([]byte, error) {
+	data = appendString(data, string(order.ClientID))
+	data = binary.AppendUvarint(data, uint64(order.Exchange))
+	data = appendString(data, string(order.Symbol))
+	data = binary.AppendUvarint(data, uint64(order.Side))
+	data = binary.AppendUvarint(data, uint64(order.Type))
+	data = binary.AppendUvarint(data, order.Amount)
+	data = binary.AppendUvarint(data, uint64(order.Price))
+	data = binary.AppendUvarint(data, uint64(order.TimeInForce))
+	data = binary.AppendUvarint(data, order.DisplayQuantity)
+	data = appendString(data, order.Tag)
+	return order.ExpiresAt.AppendBinary(data)
+}
+
+func appendString(data []byte, s string) []byte {
+	data = binary.AppendUvarint(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+func appendBool(data []byte, b bool) []byte {
+	if b {
+		return append(data, 1)
+	}
+	return append(data, 0)
+}
+
+func appendUint128(data []byte, u uint128) []byte {
+	data = binary.AppendUvarint(data, u.hi)
+	return binary.AppendUvarint(data, u.lo)
+}
+
+// binaryDecoder reads values encoded by MarshalBinary, remembering the first error.
+// Values read after an error are zero.
+type binaryDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *binaryDecoder) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: "+format, append([]any{ErrInvalidSnapshot}, args...)...)
+	}
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail("malformed unsigned integer")
+		return 0
+	}
+	d.data = d.data[n:]
+	return value
+}
+
+func (d *binaryDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	value, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail("malformed integer")
+		return 0
+	}
+	d.data = d.data[n:]
+	return value
+}
+
+// count reads a number of elements, each taking at least one byte of the remaining data.
+func (d *binaryDecoder) count() int {
+	n := d.uvarint()
+	if n > uint64(len(d.data)) {
+		d.fail("%d elements in %d bytes", n, len(d.data))
+		return 0
+	}
+	return int(n)
+}
+
+func (d *binaryDecoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
+
+func (d *binaryDecoder) bool() bool {
+	if d.err != nil {
+		return false
+	}
+	if len(d.data) == 0 || d.data[0] > 1 {
+		d.fail("malformed boolean")
+		return false
+	}
+	b := d.data[0] == 1
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *binaryDecoder) uint128() uint128 {
+	hi := d.uvarint()
+	return uint128{hi: hi, lo: d.uvarint()}
+}
+
+// time reads a time encoded by time.Time.AppendBinary, its length depends on the version byte.
+func (d *binaryDecoder) time() time.Time {
+	if d.err != nil {
+		return time.Time{}
+	}
+	n := 15
+	if len(d.data) > 0 && d.data[0] == 2 {
+		n = 16
+	}
+	if len(d.data) < n {
+		d.fail("malformed time")
+		return time.Time{}
+	}
+	var tm time.Time
+	if err := tm.UnmarshalBinary(d.data[:n]); err != nil {
+		d.fail("%v", err)
+		return time.Time{}
+	}
+	d.data = d.data[n:]
+	return tm
+}
+
+func (d *binaryDecoder) order() Order {
+	return Order{
+		ClientID:        OrderClientID(d.string()),
+		Exchange:        ExchangeID(d.uvarint()),
+		Symbol:          SymbolID(d.string()),
+		Side:            OrderSide(d.uvarint()),
+		Type:            OrderType(d.uvarint()),
+		Amount:          d.uvarint(),
+		Price:           Price(d.uvarint()),
+		TimeInForce:     TimeInForce(d.uvarint()),
+		DisplayQuantity: d.uvarint(),
+		Tag:             d.string(),
+		ExpiresAt:       d.time(),
+	}
+}
+
+// orderContext reads an order state encoded by orderContext.appendBinary.
+func (d *binaryDecoder) orderContext() *orderContext {
+	c := &orderContext{Status: OrderStatus(d.uvarint())}
+	if !c.Status.isValid() {
+		d.fail("invalid status %d", c.Status)
+	}
+	c.Order = d.order()
+	c.OriginalOrder = d.order()
+	c.LastReport.Kind = ExecutionReportKind(d.uvarint())
+	c.LastReport.Time = d.time()
+	c.StatusSince = d.time()
+	c.PlacingTime = d.time()
+	c.PlacedTime = d.time()
+	c.LastReport.Message = d.string()
+	c.LastReport.Amount = d.uvarint()
+	c.LastReport.Price = Price(d.uvarint())
+	c.LastReport.Fee = d.varint()
+	c.LastReport.RejectCode = RejectCode(d.uvarint())
+
+	if n := d.count(); n > 0 {
+		c.Fills = make([]Fill, n)
+		for i := range c.Fills {
+			c.Fills[i] = Fill{
+				Time:    d.time(),
+				TradeID: d.string(),
+				Amount:  d.uvarint(),
+				Price:   Price(d.uvarint()),
+				Fee:     d.varint(),
+			}
+		}
+	}
+	if n := d.count(); n > 0 {
+		c.TradeIDs = make(map[string]struct{}, n)
+		for range n {
+			c.TradeIDs[d.string()] = struct{}{}
+		}
+	}
+	if n := d.count(); n > 0 {
+		c.History = make([]OrderTransition, n)
+		for i := range c.History {
+			c.History[i] = OrderTransition{
+				Time:   d.time(),
+				From:   OrderStatus(d.uvarint()),
+				To:     OrderStatus(d.uvarint()),
+				Report: ExecutionReportKind(d.uvarint()),
+				Price:  Price(d.uvarint()),
+				Amount: d.uvarint(),
+			}
+		}
+	}
+
+	c.Fees = d.varint()
+	c.MovePending = d.bool()
+	c.Trimmed.Count = int(d.uvarint())
+	c.Trimmed.FirstTime = d.time()
+	c.Improvement = d.uint128()
+	c.FilledAmount = d.uvarint()
+	c.FilledValue = d.uint128()
+	c.ReportValue = d.uint128()
+	return c
+}
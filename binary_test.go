@@ -0,0 +1,163 @@
+package orderstracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newCheckpointTracker returns a tracker with n orders in various states using a fixed UTC clock,
+// so their times survive an encoding round trip unchanged.
+func newCheckpointTracker(tb testing.TB, n int) *Tracker {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker := NewTracker(WithClock(func() time.Time { return now }))
+	for i := range n {
+		order := NewOrder(OrderClientID(fmt.Sprintf("ORDER%05d", i)), ExchangeBinance, "BTCUSDT", 10, 100)
+		order.Side = SideBuy
+		order.Tag = []string{"", "mm", "arb"}[i%3]
+		if e := tracker.OrderPlacing(order); e != nil {
+			tb.Fatal(e)
+		}
+		switch i % 4 {
+		case 1:
+			if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+				tb.Fatal(e)
+			}
+			if _, e := tracker.OrderFilledWithTradeID(order.ClientID, now, fmt.Sprintf("T%d", i), 3, 99); e != nil {
+				tb.Fatal(e)
+			}
+			if _, e := tracker.OrderFilledWithFee(order.ClientID, now, 2, 98, -1); e != nil {
+				tb.Fatal(e)
+			}
+		case 2:
+			if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+				tb.Fatal(e)
+			}
+			if e := tracker.OrderCancelling(order.ClientID); e != nil {
+				tb.Fatal(e)
+			}
+		case 3:
+			if _, e := tracker.OrderRejected(order.ClientID, now, "insufficient funds"); e != nil {
+				tb.Fatal(e)
+			}
+		}
+	}
+	return tracker
+}
+
+func TestTracker_MarshalBinary(t *testing.T) {
+	tracker := newCheckpointTracker(t, 8)
+	data, e := tracker.MarshalBinary()
+	if e != nil {
+		t.Fatal(e)
+	}
+	again, e := tracker.MarshalBinary()
+	if e != nil || !reflect.DeepEqual(data, again) {
+		t.Error("Should encode the same state identically")
+	}
+
+	restored := NewTracker()
+	if _, e := restored.PushQuote(ExchangeBinance, "BTCUSDT", 99, 101); e != nil {
+		t.Fatal(e)
+	}
+	if e := restored.OrderPlacing(NewOrder("STALE", ExchangeBinance, "BTCUSDT", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	if e := restored.UnmarshalBinary(data); e != nil {
+		t.Fatal(e)
+	}
+	if got, want := restored.GetSnapshot(), tracker.GetSnapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected snapshot after round trip:\n%+v\n!=\n%+v", got, want)
+	}
+	if _, ok := restored.GetMarketQuote(ExchangeBinance, "BTCUSDT"); !ok {
+		t.Error("Should keep market data")
+	}
+	view, _ := restored.SymbolView(ExchangeBinance, "BTCUSDT")
+	if len(view.Orders) != 6 || view.Orders[0].Order.ClientID != "ORDER00000" {
+		t.Errorf("Should index restored orders by symbol: %+v", view.Orders)
+	}
+	if applied, e := restored.OrderFilledWithTradeID("ORDER00001", time.Now(), "T1", 1, 99); e != nil || applied {
+		t.Errorf("Should restore trade IDs: %v, %v", applied, e)
+	}
+}
+
+func TestTracker_UnmarshalBinaryMalformed(t *testing.T) {
+	data, e := newCheckpointTracker(t, 4).MarshalBinary()
+	if e != nil {
+		t.Fatal(e)
+	}
+	tracker := NewTracker()
+	if e := tracker.OrderPlacing(NewOrder("KEPT", ExchangeBinance, "BTCUSDT", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	malformed := [][]byte{
+		nil,
+		{0},
+		{binaryVersion, 200},
+		data[:len(data)-1],
+		append(data[:len(data):len(data)], 0),
+	}
+	for _, m := range malformed {
+		if e := tracker.UnmarshalBinary(m); !errors.Is(e, ErrInvalidSnapshot) {
+			t.Errorf("Should reject malformed data of %d bytes: %v", len(m), e)
+		}
+	}
+	if snapshot := tracker.GetSnapshot(); len(snapshot) != 1 || snapshot[0].Order.ClientID != "KEPT" {
+		t.Errorf("Should keep orders after failed decoding: %+v", snapshot)
+	}
+}
+
+func BenchmarkTracker_MarshalBinary(b *testing.B) {
+	tracker := newCheckpointTracker(b, 10000)
+	var size int
+	for b.Loop() {
+		data, e := tracker.MarshalBinary()
+		if e != nil {
+			b.Fatal(e)
+		}
+		size = len(data)
+	}
+	b.ReportMetric(float64(size), "bytes")
+}
+
+func BenchmarkTracker_MarshalJSON(b *testing.B) {
+	tracker := newCheckpointTracker(b, 10000)
+	var size int
+	for b.Loop() {
+		data, e := json.Marshal(tracker.GetSnapshot())
+		if e != nil {
+			b.Fatal(e)
+		}
+		size = len(data)
+	}
+	b.ReportMetric(float64(size), "bytes")
+}
+
+func BenchmarkTracker_UnmarshalBinary(b *testing.B) {
+	data, e := newCheckpointTracker(b, 10000).MarshalBinary()
+	if e != nil {
+		b.Fatal(e)
+	}
+	tracker := NewTracker()
+	for b.Loop() {
+		if e := tracker.UnmarshalBinary(data); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
+
+func BenchmarkTracker_UnmarshalJSON(b *testing.B) {
+	data, e := json.Marshal(newCheckpointTracker(b, 10000).GetSnapshot())
+	if e != nil {
+		b.Fatal(e)
+	}
+	for b.Loop() {
+		var snapshots []OrderSnapshot
+		if e := json.Unmarshal(data, &snapshots); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"context"
+	"time"
+)
+
+// lockContext acquires the guard unless the context is done first.
+// Returns the context error if the guard was not acquired.
+func (t *Tracker) lockContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if t.guard.TryLock() {
+		return nil
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		t.guard.Lock()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		return nil
+	case <-ctx.Done():
+		// The guard is released as soon as the pending acquisition completes
+		go func() {
+			<-acquired
+			t.guard.Unlock()
+		}()
+		return ctx.Err()
+	}
+}
+
+// OrderPlacingContext is OrderPlacing that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderPlacingContext(ctx context.Context, order Order) error {
+	if err := t.lockContext(ctx); err != nil {
+		return err
+	}
+	defer t.unlock()
+	return t.failed("OrderPlacingContext", order.ClientID, t.orderPlacing(order))
+}
+
+// OrderPlaceConfirmedContext is OrderPlaceConfirmed that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderPlaceConfirmedContext(ctx context.Context, clid OrderClientID, time time.Time) (OrderStatus, error) {
+	if err := t.lockContext(ctx); err != nil {
+		return OrderUnplaced, err
+	}
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderPlaceConfirmedContext", clid, t.orderPlaceConfirmed(clid, time))
+}
+
+// OrderRejectedContext is OrderRejected that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderRejectedContext(ctx context.Context, clid OrderClientID, time time.Time, reason string) (OrderStatus, error) {
+	if err := t.lockContext(ctx); err != nil {
+		return OrderUnplaced, err
+	}
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderRejectedContext", clid, t.orderRejected(clid, time, RejectUnknown, reason))
+}
+
+// OrderMovingContext is OrderMoving that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderMovingContext(ctx context.Context, clid OrderClientID) error {
+	if err := t.lockContext(ctx); err != nil {
+		return err
+	}
+	defer t.unlock()
+	return t.failed("OrderMovingContext", clid, t.orderMoving(clid))
+}
+
+// OrderMoveConfirmedContext is OrderMoveConfirmed that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderMoveConfirmedContext(ctx context.Context, clid OrderClientID, time time.Time, price Price) (OrderStatus, error) {
+	if err := t.lockContext(ctx); err != nil {
+		return OrderUnplaced, err
+	}
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderMoveConfirmedContext", clid, t.orderMoveConfirmed(clid, time, price))
+}
+
+// OrderCancellingContext is OrderCancelling that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderCancellingContext(ctx context.Context, clid OrderClientID) error {
+	if err := t.lockContext(ctx); err != nil {
+		return err
+	}
+	defer t.unlock()
+	return t.failed("OrderCancellingContext", clid, t.orderCancelling(clid))
+}
+
+// OrderCancelConfirmedContext is OrderCancelConfirmed that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderCancelConfirmedContext(ctx context.Context, clid OrderClientID, time time.Time) (OrderStatus, error) {
+	if err := t.lockContext(ctx); err != nil {
+		return OrderUnplaced, err
+	}
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderCancelConfirmedContext", clid, t.orderCancelConfirmedWithReason(clid, time, ""))
+}
+
+// OrderFilledContext is OrderFilled that gives up waiting for the guard when the context is done.
+func (t *Tracker) OrderFilledContext(ctx context.Context, clid OrderClientID, time time.Time,
+	executedAmount uint64, avgPrice Price) (bool, error) {
+	if err := t.lockContext(ctx); err != nil {
+		return false, err
+	}
+	defer t.unlock()
+	complete, err := t.orderFilled(clid, time, executedAmount, avgPrice)
+	return complete, t.failed("OrderFilledContext", clid, err)
+}
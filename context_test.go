@@ -0,0 +1,50 @@
+package orderstracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTracker_OrderPlacingContext(t *testing.T) {
+	tracker := NewTracker()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacingContext(context.Background(), order); e != nil {
+		t.Fatal(e)
+	}
+	if tracker.GetOrdersCount() != 1 {
+		t.Error("Should place order with live context")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if e := tracker.OrderPlacingContext(canceled, GenerateOrderWithSymbol("TEST")); !errors.Is(e, context.Canceled) {
+		t.Errorf("Should not place order with canceled context: %v", e)
+	}
+	if tracker.GetOrdersCount() != 1 {
+		t.Error("Should not place order with canceled context")
+	}
+}
+
+func TestTracker_OrderPlacingContextCongested(t *testing.T) {
+	tracker := NewTracker()
+	tracker.guard.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	e := tracker.OrderPlacingContext(ctx, GenerateOrderWithSymbol("TEST"))
+	if !errors.Is(e, context.DeadlineExceeded) {
+		t.Errorf("Should give up waiting for congested tracker: %v", e)
+	}
+	tracker.guard.Unlock()
+
+	// The abandoned acquisition should release the guard
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if e := tracker.OrderPlacingContext(ctx, GenerateOrderWithSymbol("TEST")); e != nil {
+		t.Error(e)
+	}
+	if tracker.GetOrdersCount() != 1 {
+		t.Error("Should place order once the guard is released")
+	}
+}
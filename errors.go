@@ -0,0 +1,37 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "errors"
+
+var (
+	// ErrHalted is returned by mutating methods while the tracker is halted.
+	ErrHalted = errors.New("tracker is halted")
+	// ErrInvalidQuote is returned when validation is enabled and a quote is malformed.
+	ErrInvalidQuote = errors.New("invalid quote")
+	// ErrInvalidOrder is returned when validation is enabled and an order is malformed.
+	ErrInvalidOrder = errors.New("invalid order")
+	// ErrOrderNotFound is returned when an order with the given client ID is not tracked.
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrTooManyOrders is returned when placing an order would exceed the limit set with WithMaxOrders.
+	ErrTooManyOrders = errors.New("too many active orders")
+	// ErrExchangeDisconnected is returned when placing an order on an exchange marked as disconnected.
+	ErrExchangeDisconnected = errors.New("exchange is disconnected")
+	// ErrFillNotPlaced is returned when strict fills are enabled and a fill arrives
+	// for an order not confirmed placed.
+	ErrFillNotPlaced = errors.New("fill of order not placed")
+	// ErrInvalidFill is returned when a fill has zero amount.
+	ErrInvalidFill = errors.New("invalid fill")
+	// ErrOverfill is returned when a fill exceeds the remaining amount of the order.
+	ErrOverfill = errors.New("fill exceeds remaining amount")
+	// ErrInvalidTransition is returned when the order status does not allow the requested change,
+	// see CanTransition.
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	// ErrTrackerClosed is returned by mutating methods after Close.
+	ErrTrackerClosed = errors.New("tracker is closed")
+	// ErrInvalidPrice is returned when a decimal price can not be parsed.
+	ErrInvalidPrice = errors.New("invalid price")
+	// ErrInvalidSnapshot is returned by UnmarshalBinary when the data is malformed.
+	ErrInvalidSnapshot = errors.New("invalid binary snapshot")
+)
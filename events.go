@@ -0,0 +1,291 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// EventKind defines the mutating call recorded by an Event.
+type EventKind int
+
+const (
+	EventNone EventKind = iota
+	EventPlacing
+	EventPlaceConfirmed
+	EventRejected
+	EventMoving
+	EventMoveConfirmed
+	EventCancelling
+	EventCancelConfirmed
+	EventExpired
+	EventFilled
+	EventFilledWithTradeID
+	EventQuote
+	EventReset
+	EventHalt
+	EventUnhalt
+	EventPartialCancelConfirmed
+	EventFilledWithFee
+	EventPurge
+	EventDisconnected
+	EventReconnected
+	EventUnknown
+	EventResolved
+	EventTrimHistory
+	EventPlaced
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventPlacing:
+		return "Placing"
+	case EventPlaceConfirmed:
+		return "PlaceConfirmed"
+	case EventRejected:
+		return "Rejected"
+	case EventMoving:
+		return "Moving"
+	case EventMoveConfirmed:
+		return "MoveConfirmed"
+	case EventCancelling:
+		return "Cancelling"
+	case EventCancelConfirmed:
+		return "CancelConfirmed"
+	case EventExpired:
+		return "Expired"
+	case EventFilled:
+		return "Filled"
+	case EventFilledWithTradeID:
+		return "FilledWithTradeID"
+	case EventQuote:
+		return "Quote"
+	case EventReset:
+		return "Reset"
+	case EventHalt:
+		return "Halt"
+	case EventUnhalt:
+		return "Unhalt"
+	case EventPartialCancelConfirmed:
+		return "PartialCancelConfirmed"
+	case EventFilledWithFee:
+		return "FilledWithFee"
+	case EventPurge:
+		return "Purge"
+	case EventDisconnected:
+		return "Disconnected"
+	case EventReconnected:
+		return "Reconnected"
+	case EventUnknown:
+		return "Unknown"
+	case EventResolved:
+		return "Resolved"
+	case EventTrimHistory:
+		return "TrimHistory"
+	case EventPlaced:
+		return "Placed"
+	default:
+		return "None"
+	}
+}
+
+// Event is a record of a successful mutating call.
+// Time is the time passed to the call (the purge boundary for EventPurge) or, for calls taking the time from the tracker clock
+// (OrderPlacing, OrderMoving, OrderCancelling, TrimHistory), the clock reading at the call.
+// Fields besides Kind, ClientID and Time are set only for the kinds using them.
+type Event struct {
+	Kind       EventKind
+	ClientID   OrderClientID
+	Time       time.Time
+	Order      Order       // EventPlacing
+	Reason     string      // EventRejected, EventCancelConfirmed, EventHalt
+	TradeID    string      // EventFilledWithTradeID
+	Amount     uint64      // EventFilled, EventFilledWithTradeID, EventFilledWithFee, EventPartialCancelConfirmed, EventTrimHistory (fill limit)
+	Price      Price       // EventMoveConfirmed, EventFilled, EventFilledWithTradeID, EventFilledWithFee
+	Fee        int64       // EventFilledWithFee
+	Exchange   ExchangeID  // EventQuote, EventDisconnected, EventReconnected
+	Symbol     SymbolID    // EventQuote
+	Bid        Price       // EventQuote
+	Ask        Price       // EventQuote
+	BidSize    uint64      // EventQuote
+	AskSize    uint64      // EventQuote
+	Status     OrderStatus // EventResolved
+	RejectCode RejectCode  // EventRejected
+}
+
+// eventLog is an append-only sink of events: a writer of JSON lines, an in-memory ring or both.
+type eventLog struct {
+	encoder *json.Encoder
+	ring    []Event
+	next    int
+	full    bool
+}
+
+// append writes the event into the sinks of the log.
+func (l *eventLog) append(event Event) error {
+	if l.ring != nil {
+		l.ring[l.next] = event
+		l.next++
+		if l.next == len(l.ring) {
+			l.next = 0
+			l.full = true
+		}
+	}
+	if l.encoder != nil {
+		return l.encoder.Encode(&event)
+	}
+	return nil
+}
+
+// events returns the events kept in the ring, oldest first.
+func (l *eventLog) events() []Event {
+	if !l.full {
+		return append([]Event(nil), l.ring[:l.next]...)
+	}
+	events := make([]Event, 0, len(l.ring))
+	events = append(events, l.ring[l.next:]...)
+	return append(events, l.ring[:l.next]...)
+}
+
+// emit records the event if the event log is enabled, the guard should be held.
+// The event is written after the call has changed the state, so the call whose event
+// fails to be written is kept in memory but missing from the log. The failure halts
+// the tracker, so no further call widens the gap.
+func (t *Tracker) emit(event Event) {
+	if t.events == nil {
+		return
+	}
+	if err := t.events.append(event); err != nil {
+		t.halted = true
+		t.haltReason = fmt.Sprintf("event log: %v", err)
+	}
+}
+
+// Events returns the events kept by the in-memory ring enabled with WithEventRing, oldest first.
+// Returns nil if the ring is not enabled.
+func (t *Tracker) Events() []Event {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	if t.events == nil || t.events.ring == nil {
+		return nil
+	}
+	return t.events.events()
+}
+
+// ReadEvents decodes the events written as JSON lines by the WithEventLog writer.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	decoder := json.NewDecoder(r)
+	for {
+		var event Event
+		err := decoder.Decode(&event)
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+}
+
+// ReplayEvents reconstructs the tracker state by applying the events in order to a new tracker
+// created with the options. The tracker clock is set to the time of each event while it is applied,
+// and restored to the configured clock afterwards. Options affecting the state, like WithVWAPRounding,
+// are not recorded in the events, so the result has the same snapshot as the tracker recorded
+// the events from only if it is replayed with the same options.
+// Background goroutines of options like WithAutoExpire and WithAutoPurge are started once
+// all events are applied, so they don't change the state in the middle of the replay.
+// Returns an error if an event can not be applied.
+func ReplayEvents(events []Event, opts ...Option) (*Tracker, error) {
+	t := newTracker(opts...)
+	now := t.now
+	for i, event := range events {
+		if err := t.apply(event); err != nil {
+			return nil, fmt.Errorf("replay event %d (kind %v, clid %v): %w", i, event.Kind, event.ClientID, err)
+		}
+	}
+	t.guard.Lock()
+	t.now = now
+	t.guard.Unlock()
+	t.start()
+	return t, nil
+}
+
+// apply applies the event to the tracker.
+func (t *Tracker) apply(event Event) error {
+	t.guard.Lock()
+	defer t.unlock()
+
+	t.now = func() time.Time { return event.Time }
+	switch event.Kind {
+	case EventPlacing:
+		return t.orderPlacing(event.Order)
+	case EventPlaceConfirmed:
+		return t.orderPlaceConfirmed(event.ClientID, event.Time)
+	case EventPlaced:
+		return t.placeConfirmed(event.ClientID, event.Time, EventPlaced)
+	case EventRejected:
+		return t.orderRejected(event.ClientID, event.Time, event.RejectCode, event.Reason)
+	case EventMoving:
+		return t.orderMoving(event.ClientID)
+	case EventMoveConfirmed:
+		return t.orderMoveConfirmed(event.ClientID, event.Time, event.Price)
+	case EventCancelling:
+		return t.orderCancelling(event.ClientID)
+	case EventCancelConfirmed:
+		return t.orderCancelConfirmedWithReason(event.ClientID, event.Time, event.Reason)
+	case EventExpired:
+		return t.orderExpired(event.ClientID, event.Time)
+	case EventFilled:
+		_, err := t.orderFilled(event.ClientID, event.Time, event.Amount, event.Price)
+		return err
+	case EventFilledWithTradeID:
+		_, err := t.orderFilledWithTradeID(event.ClientID, event.Time, event.TradeID, event.Amount, event.Price)
+		return err
+	case EventQuote:
+		_, err := t.pushQuote(event.Exchange, event.Symbol, event.Bid, event.Ask, event.BidSize, event.AskSize)
+		return err
+	case EventReset:
+		t.reset()
+		return nil
+	case EventHalt:
+		t.halt(event.Reason)
+		return nil
+	case EventUnhalt:
+		t.unhalt()
+		return nil
+	case EventPartialCancelConfirmed:
+		return t.orderPartialCancelConfirmed(event.ClientID, event.Time, event.Amount)
+	case EventFilledWithFee:
+		_, err := t.orderFilledWithFee(event.ClientID, event.Time, event.Amount, event.Price, event.Fee)
+		return err
+	case EventPurge:
+		t.purgeCompleted(event.Time)
+		return nil
+	case EventDisconnected:
+		t.offline[event.Exchange] = struct{}{}
+		return nil
+	case EventReconnected:
+		delete(t.offline, event.Exchange)
+		return nil
+	case EventUnknown:
+		return t.markOrderUnknown(event.ClientID, event.Time)
+	case EventResolved:
+		return t.resolveUnknown(event.ClientID, event.Time, event.Status)
+	case EventTrimHistory:
+		// Events recorded without the limit don't trim
+		if event.Amount > 0 {
+			t.trimHistory(int(event.Amount))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown event kind %d", event.Kind)
+	}
+}
@@ -0,0 +1,222 @@
+package orderstracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_ReplayEvents(t *testing.T) {
+	var log bytes.Buffer
+	tracker := NewTracker(WithEventLog(&log), WithEventRing(64))
+	start := time.Now()
+
+	first := NewOrder("FIRST", ExchangeBinance, "BTCUSDT", 10, 100)
+	second := NewOrder("SECOND", ExchangeKraken, "ETHUSDT", 5, 50)
+	third := NewOrder("THIRD", ExchangeBinance, "ETHUSDT", 1, 20)
+	for _, order := range []Order{first, second, third} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	transition := func(_ OrderStatus, e error) error { return e }
+	filled := func(_ bool, e error) error { return e }
+	signaled := func(_ QuoteSignals, e error) error { return e }
+	steps := []error{
+		signaled(tracker.PushQuote(ExchangeBinance, "BTCUSDT", 99, 101)),
+		transition(tracker.OrderPlaceConfirmed(first.ClientID, start.Add(time.Millisecond))),
+		transition(tracker.OrderPlaceConfirmed(second.ClientID, start.Add(2*time.Millisecond))),
+		transition(tracker.OrderRejected(third.ClientID, start.Add(3*time.Millisecond), "no funds")),
+		tracker.OrderMoving(first.ClientID),
+		transition(tracker.OrderMoveConfirmed(first.ClientID, start.Add(4*time.Millisecond), 102)),
+		filled(tracker.OrderFilled(first.ClientID, start.Add(5*time.Millisecond), 4, 102)),
+		tracker.OrderCancelling(second.ClientID),
+		transition(tracker.OrderCancelConfirmedWithReason(second.ClientID, start.Add(6*time.Millisecond), "user")),
+	}
+	for i, e := range steps {
+		if e != nil {
+			t.Fatalf("step %d: %v", i, e)
+		}
+	}
+	if _, e := tracker.OrderFilledWithTradeID(first.ClientID, start.Add(7*time.Millisecond), "T1", 6, 101); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving(second.ClientID); e == nil {
+		t.Fatal("Should fail to move canceled order")
+	}
+
+	want, e := json.Marshal(tracker.GetSnapshot())
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	written, e := ReadEvents(&log)
+	if e != nil {
+		t.Fatal(e)
+	}
+	ringed := tracker.Events()
+	if len(written) != 13 || len(ringed) != len(written) {
+		t.Fatalf("Should record every successful call: written %d, ringed %d", len(written), len(ringed))
+	}
+	for name, events := range map[string][]Event{"written": written, "ringed": ringed} {
+		replayed, e := ReplayEvents(events)
+		if e != nil {
+			t.Fatalf("%s: %v", name, e)
+		}
+		got, e := json.Marshal(replayed.GetSnapshot())
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Replay of %s events should produce identical snapshot:\n%s\n%s", name, got, want)
+		}
+	}
+}
+
+func Test_ReplayEventsWithOptions(t *testing.T) {
+	tracker := NewTracker(WithVWAPRounding(VWAPRoundHalfUp), WithEventRing(16))
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("ROUNDED", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	for _, price := range []Price{100, 101} {
+		if _, e := tracker.OrderFilled(order.ClientID, now, 1, price); e != nil {
+			t.Fatal(e)
+		}
+	}
+	want, _ := tracker.GetExecutionReport(order.ClientID)
+	if want.Price != 101 {
+		t.Fatalf("Should round the aggregated price half up: %v", want.Price)
+	}
+
+	replayed, e := ReplayEvents(tracker.Events(), WithVWAPRounding(VWAPRoundHalfUp))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, _ := replayed.GetExecutionReport(order.ClientID); got != want {
+		t.Errorf("Should replay with the given options: %+v != %+v", got, want)
+	}
+	clock := func() time.Time { return now }
+	replayed, e = ReplayEvents(tracker.Events(), WithClock(clock))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, _ := replayed.GetExecutionReport(order.ClientID); got.Price != 100 {
+		t.Errorf("Should truncate the aggregated price by default: %v", got.Price)
+	}
+	if e := replayed.OrderPlacing(NewOrder("AFTER", ExchangeBinance, "TEST", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	if updated, e := replayed.LastUpdateTime("AFTER"); e != nil || !updated.Equal(now) {
+		t.Errorf("Should keep the configured clock after replay: %v, %v", updated, e)
+	}
+}
+
+func Test_ReplayEventsWithBackgroundOptions(t *testing.T) {
+	tracker := NewTracker(WithEventRing(1000))
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	for i := range 300 {
+		order := NewOrder(OrderClientID(fmt.Sprint(i)), ExchangeBinance, "TEST", 1, 100)
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderExpired(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	// Purging expired orders in the middle of the replay would fail their re-placements
+	replayed, e := ReplayEvents(tracker.Events(), WithAutoExpire(time.Microsecond),
+		WithAutoPurge(time.Microsecond, 0))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer replayed.Close()
+	if e := replayed.OrderPlacing(NewOrder("AFTER", ExchangeBinance, "TEST", 1, 100)); e != nil {
+		t.Errorf("Should keep replayed tracker usable: %v", e)
+	}
+}
+
+func Test_ReplayEventsInvalid(t *testing.T) {
+	events := []Event{{Kind: EventPlaceConfirmed, ClientID: "UNKNOWN", Time: time.Now()}}
+	if _, e := ReplayEvents(events); e == nil {
+		t.Error("Should fail to replay confirmation of unknown order")
+	}
+}
+
+func TestTracker_EventRingWraps(t *testing.T) {
+	tracker := NewTracker(WithEventRing(2))
+	for _, clid := range []OrderClientID{"A", "B", "C"} {
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 1)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	events := tracker.Events()
+	if len(events) != 2 || events[0].ClientID != "B" || events[1].ClientID != "C" {
+		t.Errorf("Should keep the last events oldest first: %+v", events)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// flakyWriter accepts n writes into the buffer and fails afterwards.
+type flakyWriter struct {
+	n      int
+	buffer bytes.Buffer
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return w.buffer.Write(p)
+}
+
+func TestTracker_EventLogFailureKeepsFailedCall(t *testing.T) {
+	sink := &flakyWriter{n: 1}
+	tracker := NewTracker(WithEventLog(sink))
+	if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); !errors.Is(e, ErrHalted) {
+		t.Errorf("Should refuse calls after the event log failure, got %v", e)
+	}
+	if tracker.GetOrdersCount() != 2 {
+		t.Errorf("Should keep the call whose event failed, got %v orders", tracker.GetOrdersCount())
+	}
+	events, e := ReadEvents(&sink.buffer)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(events) != 1 {
+		t.Errorf("Should log the events written before the failure only, got %v", len(events))
+	}
+}
+
+func TestTracker_EventLogFailureHalts(t *testing.T) {
+	tracker := NewTracker(WithEventLog(failingWriter{}))
+	if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+		t.Fatal(e)
+	}
+	if halted, _ := tracker.IsHalted(); !halted {
+		t.Error("Should halt when event log fails")
+	}
+}
@@ -3,7 +3,10 @@
 
 package orderstracker
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type ExecutionReportKind int
 
@@ -14,12 +17,76 @@ const (
 	ReportCanceled
 	ReportFilled
 	ReportRejected
+	ReportExpired
 )
 
+func (k ExecutionReportKind) String() string {
+	switch k {
+	case ReportNone:
+		return "None"
+	case ReportPlaced:
+		return "Placed"
+	case ReportModified:
+		return "Modified"
+	case ReportCanceled:
+		return "Canceled"
+	case ReportFilled:
+		return "Filled"
+	case ReportRejected:
+		return "Rejected"
+	case ReportExpired:
+		return "Expired"
+	default:
+		return "Unknown"
+	}
+}
+
+// RejectCode categorizes the reason of a rejection, so strategies can react to it programmatically,
+// for example backing off when throttled.
+type RejectCode int
+
+const (
+	RejectUnknown RejectCode = iota
+	RejectRiskLimit
+	RejectInvalidPrice
+	RejectDuplicate
+	RejectThrottled
+)
+
+func (c RejectCode) String() string {
+	switch c {
+	case RejectUnknown:
+		return "Unknown"
+	case RejectRiskLimit:
+		return "RiskLimit"
+	case RejectInvalidPrice:
+		return "InvalidPrice"
+	case RejectDuplicate:
+		return "Duplicate"
+	case RejectThrottled:
+		return "Throttled"
+	default:
+		return fmt.Sprintf("RejectCode(%d)", int(c))
+	}
+}
+
+// ExecutionReport is the latest report of an order.
+// Fee is the total fee of the fills aggregated in a ReportFilled report, negative for rebates.
+// RejectCode is meaningful for ReportRejected reports only.
 type ExecutionReport struct {
-	Kind    ExecutionReportKind
+	Kind       ExecutionReportKind
+	Time       time.Time
+	Message    string
+	Amount     uint64
+	Price      Price
+	Fee        int64
+	RejectCode RejectCode
+}
+
+type Fill struct {
 	Time    time.Time
-	Message string
+	TradeID string
 	Amount  uint64
-	Price   uint64
+	Price   Price
+	Fee     int64
 }
@@ -0,0 +1,45 @@
+package orderstracker
+
+import "testing"
+
+func Test_ExecutionReportKindString(t *testing.T) {
+	tests := []struct {
+		kind ExecutionReportKind
+		want string
+	}{
+		{ReportNone, "None"},
+		{ReportPlaced, "Placed"},
+		{ReportModified, "Modified"},
+		{ReportCanceled, "Canceled"},
+		{ReportFilled, "Filled"},
+		{ReportRejected, "Rejected"},
+		{ReportExpired, "Expired"},
+		{ReportExpired + 1, "Unknown"},
+		{-1, "Unknown"},
+	}
+	for _, test := range tests {
+		if got := test.kind.String(); got != test.want {
+			t.Errorf("Unexpected name of kind %d: %s != %s", int(test.kind), got, test.want)
+		}
+	}
+}
+
+func Test_RejectCodeString(t *testing.T) {
+	tests := []struct {
+		code RejectCode
+		want string
+	}{
+		{RejectUnknown, "Unknown"},
+		{RejectRiskLimit, "RiskLimit"},
+		{RejectInvalidPrice, "InvalidPrice"},
+		{RejectDuplicate, "Duplicate"},
+		{RejectThrottled, "Throttled"},
+		{RejectThrottled + 1, "RejectCode(5)"},
+		{-1, "RejectCode(-1)"},
+	}
+	for _, test := range tests {
+		if got := test.code.String(); got != test.want {
+			t.Errorf("Unexpected name of code %d: %s != %s", int(test.code), got, test.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// OrderTransition describes a single change applied to an order:
+// the status before and after it and the execution report resulting from the change.
+type OrderTransition struct {
+	Time   time.Time
+	From   OrderStatus
+	To     OrderStatus
+	Report ExecutionReportKind
+	Price  Price
+	Amount uint64
+}
+
+// record appends the transition from the given status into the current one to the order history.
+func (c *orderContext) record(from OrderStatus, time time.Time) {
+	c.History = append(c.History, OrderTransition{
+		Time:   time,
+		From:   from,
+		To:     c.Status,
+		Report: c.LastReport.Kind,
+		Price:  c.LastReport.Price,
+		Amount: c.LastReport.Amount,
+	})
+}
+
+// recordFill records the fill changing the order from the given status like record,
+// but consecutive partial fills keeping the status update the entry of the first one
+// with the aggregated report, so the history doesn't grow with the number of fills.
+func (c *orderContext) recordFill(from OrderStatus, time time.Time) {
+	if n := len(c.History); n > 0 && from == c.Status {
+		last := &c.History[n-1]
+		if last.From == from && last.To == from && last.Report == ReportFilled {
+			last.Time = time
+			last.Price = c.LastReport.Price
+			last.Amount = c.LastReport.Amount
+			return
+		}
+	}
+	c.record(from, time)
+}
+
+// GetOrderHistory returns a copy of the transitions applied to the order, oldest first.
+// Consecutive partial fills keeping the order status are reported by a single transition
+// with the aggregated report.
+// Returns an error if the order is not found.
+func (t *Tracker) GetOrderHistory(clid OrderClientID) ([]OrderTransition, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return nil, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	history := make([]OrderTransition, len(orderContext.History))
+	copy(history, orderContext.History)
+	return history, nil
+}
+
+// WriteOrderHistoryCSV writes the order history as CSV with the header
+// time,from_status,to_status,report_kind,price,amount followed by one row per transition.
+// An order without transitions produces the header only.
+// Returns an error if the order is not found or writing fails.
+func (t *Tracker) WriteOrderHistoryCSV(w io.Writer, clid OrderClientID) error {
+	history, err := t.GetOrderHistory(clid)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"time", "from_status", "to_status", "report_kind", "price", "amount"}); err != nil {
+		return err
+	}
+	for _, transition := range history {
+		record := []string{
+			transition.Time.Format(time.RFC3339Nano),
+			transition.From.String(),
+			transition.To.String(),
+			transition.Report.String(),
+			strconv.FormatUint(uint64(transition.Price), 10),
+			strconv.FormatUint(transition.Amount, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,69 @@
+package orderstracker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestTracker_WriteOrderHistoryCSV(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return start }
+	order := GenerateOrderWithSymbol("TEST")
+	order.Amount = 7
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start.Add(time.Second)); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, start.Add(2*time.Second), 42); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, start.Add(3*time.Second), 7, 42); e != nil {
+		t.Fatal(e)
+	}
+
+	var buffer bytes.Buffer
+	if e := tracker.WriteOrderHistoryCSV(&buffer, order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	rows, e := csv.NewReader(&buffer).ReadAll()
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := [][]string{
+		{"time", "from_status", "to_status", "report_kind", "price", "amount"},
+		{"2025-04-12T10:00:00Z", "Unplaced", "Placing", "None", "0", "0"},
+		{"2025-04-12T10:00:01Z", "Placing", "Placed", "Placed", "0", "0"},
+		{"2025-04-12T10:00:00Z", "Placed", "Modifying", "None", "0", "0"},
+		{"2025-04-12T10:00:02Z", "Modifying", "Placed", "Modified", "42", "0"},
+		{"2025-04-12T10:00:03Z", "Placed", "Filled", "Filled", "42", "7"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Unexpected number of rows: %v", rows)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Errorf("Unexpected value at row %d column %d: %v != %v", i, j, rows[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestTracker_WriteOrderHistoryCSVUnknownOrder(t *testing.T) {
+	tracker := NewTracker()
+	var buffer bytes.Buffer
+	if e := tracker.WriteOrderHistoryCSV(&buffer, "unknown"); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+	if buffer.Len() != 0 {
+		t.Error("Should not write anything for unknown order")
+	}
+}
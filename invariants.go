@@ -0,0 +1,59 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "fmt"
+
+// CheckInvariants verifies the internal consistency of the tracker and returns every violation found:
+// nil order contexts, orders tracked in market data but missing from orders or vice versa,
+// orders tracked under a wrong exchange or symbol, invalid statuses and fills exceeding order amounts.
+// It returns nil for a consistent tracker. It walks all orders under the guard,
+// so it is intended for debugging and periodic health checks rather than hot paths.
+func (t *Tracker) CheckInvariants() []error {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var violations []error
+	for clid, orderContext := range t.orders {
+		if orderContext == nil {
+			violations = append(violations, fmt.Errorf("nil order context (clid %v)", clid))
+			continue
+		}
+		order := &orderContext.Order
+		if order.ClientID != clid {
+			violations = append(violations, fmt.Errorf("order stored under another client ID (clid %v, order clid %v)",
+				clid, order.ClientID))
+		}
+		if t.exchanges[order.Exchange][order.Symbol].orders[clid] != orderContext {
+			violations = append(violations, fmt.Errorf("order is not tracked in market data (clid %v, exchange %v, symbol %v)",
+				clid, order.Exchange, order.Symbol))
+		}
+		if !orderContext.Status.isValid() {
+			violations = append(violations, fmt.Errorf("invalid order status %d (clid %v)", int(orderContext.Status), clid))
+		}
+		if filledAmount, _ := orderContext.filled(); filledAmount > order.Amount {
+			violations = append(violations, fmt.Errorf("filled amount %d exceeds order amount %d (clid %v)",
+				filledAmount, order.Amount, clid))
+		}
+	}
+
+	for exchangeID, exchange := range t.exchanges {
+		for symbolID, symbolContext := range exchange {
+			for clid, orderContext := range symbolContext.orders {
+				switch {
+				case orderContext == nil:
+					violations = append(violations, fmt.Errorf("nil order context in market data (clid %v, exchange %v, symbol %v)",
+						clid, exchangeID, symbolID))
+				case t.orders[clid] != orderContext:
+					violations = append(violations, fmt.Errorf("order in market data is not tracked (clid %v, exchange %v, symbol %v)",
+						clid, exchangeID, symbolID))
+				case orderContext.Order.Exchange != exchangeID || orderContext.Order.Symbol != symbolID:
+					violations = append(violations, fmt.Errorf("order is tracked under another symbol (clid %v, exchange %v, symbol %v)",
+						clid, exchangeID, symbolID))
+				}
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,45 @@
+package orderstracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTracker_CheckInvariants(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	for range 3 {
+		order := GenerateOrderWithSymbol("TEST")
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, order.Amount, order.Price); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if violations := tracker.CheckInvariants(); len(violations) != 0 {
+		t.Fatalf("Consistent tracker should have no violations: %v", violations)
+	}
+
+	overfilled := NewOrder("OVERFILLED", ExchangeBinance, "TEST", 1, 100)
+	invalid := NewOrder("INVALID", ExchangeBinance, "TEST", 1, 100)
+	orphaned := NewOrder("ORPHANED", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{overfilled, invalid, orphaned} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderFilled(overfilled.ClientID, now, 2, 100); !errors.Is(e, ErrOverfill) {
+		t.Errorf("Should reject overfill: %v", e)
+	}
+	// Corrupting the totals as the tracker doesn't accept overfills
+	tracker.orders[overfilled.ClientID].FilledAmount = 2
+	tracker.orders[invalid.ClientID].Status = OrderStatus(42)
+	delete(tracker.orders, orphaned.ClientID)
+	tracker.orders["NIL"] = nil
+
+	if violations := tracker.CheckInvariants(); len(violations) != 4 {
+		t.Errorf("Should report every violation: %v", violations)
+	}
+}
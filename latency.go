@@ -0,0 +1,46 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"math"
+	"time"
+)
+
+// latencyStats accumulates the mean and variance of latency samples
+// using Welford's streaming algorithm, so memory usage stays constant
+// regardless of the number of samples.
+type latencyStats struct {
+	count uint64
+	mean  float64
+	m2    float64
+}
+
+// add includes a new sample into the running mean and variance.
+func (s *latencyStats) add(sample time.Duration) {
+	s.count++
+	x := float64(sample)
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+}
+
+// stddev returns the population standard deviation of the samples seen so far.
+func (s *latencyStats) stddev() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return math.Sqrt(s.m2 / float64(s.count))
+}
+
+// AckJitter returns the mean and standard deviation of the placement acknowledgment
+// latency (time between OrderPlacing and OrderPlaceConfirmed) for the given exchange.
+// Both values are zero if no placement has been confirmed on the exchange yet.
+func (t *Tracker) AckJitter(exchange ExchangeID) (mean, stddev time.Duration) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	stats := t.ackLatency[exchange]
+	return time.Duration(stats.mean), time.Duration(stats.stddev())
+}
@@ -0,0 +1,40 @@
+package orderstracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_AckJitter(t *testing.T) {
+	tracker := NewTracker()
+	placingTime := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return placingTime }
+	latencies := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		30 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+	}
+	for _, latency := range latencies {
+		order := GenerateOrderWithSymbol("TEST")
+		order.Exchange = ExchangeBinance
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, placingTime.Add(latency)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	mean, stddev := tracker.AckJitter(ExchangeBinance)
+	if mean != 30*time.Millisecond {
+		t.Errorf("Unexpected mean latency: %v", mean)
+	}
+	wantStddev := 14142136 * time.Nanosecond // sqrt(200) ms
+	if diff := stddev - wantStddev; diff < -time.Microsecond || diff > time.Microsecond {
+		t.Errorf("Unexpected latency stddev: %v != %v", stddev, wantStddev)
+	}
+	if mean, stddev := tracker.AckJitter(ExchangeKraken); mean != 0 || stddev != 0 {
+		t.Error("Should not report latency for exchange without confirmations")
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "time"
+
+// Close shuts the tracker down: it makes mutating methods fail with ErrTrackerClosed,
+// stops the background goroutines started by WithAutoPurge, WithAutoExpire and
+// WithCoalescedNotifications waiting for them to exit, delivering the coalesced status changes,
+// and closes the channels returned by SubscribeFills. Queries keep working on the final state.
+// It must be called when a tracker created with these options is discarded, otherwise
+// the goroutines leak. Calling Close more than once does nothing. It always returns nil.
+func (t *Tracker) Close() error {
+	t.closeOnce.Do(func() {
+		t.guard.Lock()
+		t.closed = true
+		t.guard.Unlock()
+
+		if t.stop != nil {
+			close(t.stop)
+			t.background.Wait()
+		}
+
+		t.notifyGuard.Lock()
+		defer t.notifyGuard.Unlock()
+		for _, subscription := range t.fillSubscriptions {
+			close(subscription)
+		}
+		t.fillSubscriptions = nil
+		t.subscriptionsClosed = true
+	})
+	return nil
+}
+
+// autoPurge purges orders completed more than retain ago every interval until Close is called.
+func (t *Tracker) autoPurge(interval time.Duration, retain time.Duration) {
+	defer t.background.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.purgeRetained(retain)
+		}
+	}
+}
+
+// autoExpire calls ExpireOrders with the tracker clock every interval until Close is called.
+func (t *Tracker) autoExpire(interval time.Duration) {
+	defer t.background.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.ExpireOrders(t.now())
+		}
+	}
+}
+
+// deliverCoalesced flushes the coalesced status changes every interval until Close is called,
+// when the remaining ones are flushed.
+func (t *Tracker) deliverCoalesced(interval time.Duration) {
+	defer t.background.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			t.flushCoalesced()
+			return
+		case <-ticker.C:
+			t.flushCoalesced()
+		}
+	}
+}
+
+// purgeRetained removes orders completed more than retain ago by the tracker clock.
+// Nothing is purged nor recorded in the event log if there are no such orders,
+// so idle ticks don't flood the log with purge events.
+func (t *Tracker) purgeRetained(retain time.Duration) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	if t.writable() != nil {
+		return
+	}
+	before := t.now().Add(-retain)
+	for _, orderContext := range t.orders {
+		if !orderContext.Status.isActive() && orderContext.StatusSince.Before(before) {
+			t.purgeCompleted(before)
+			return
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package orderstracker
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTracker_WithAutoPurge(t *testing.T) {
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	var elapsed atomic.Int64
+	clock := func() time.Time { return start.Add(time.Duration(elapsed.Load())) }
+	tracker := NewTracker(WithClock(clock), WithAutoPurge(time.Millisecond, time.Minute), WithEventRing(16))
+	defer tracker.Close()
+
+	for _, clid := range []OrderClientID{"REJECTED", "ACTIVE"} {
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 100)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("REJECTED", start, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if tracker.GetOrdersCount() != 2 {
+		t.Fatal("Should not purge orders within the retention window")
+	}
+	for _, event := range tracker.Events() {
+		if event.Kind == EventPurge {
+			t.Fatal("Should not record purges without purged orders")
+		}
+	}
+
+	elapsed.Store(int64(2 * time.Minute))
+	deadline := time.Now().Add(5 * time.Second)
+	for tracker.GetOrdersCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Should purge terminal orders after the retention window")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if active := tracker.GetActiveOrders(); len(active) != 1 || active[0] != "ACTIVE" {
+		t.Errorf("Should keep active orders: %v", active)
+	}
+}
+
+func TestTracker_WithAutoExpire(t *testing.T) {
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	var elapsed atomic.Int64
+	clock := func() time.Time { return start.Add(time.Duration(elapsed.Load())) }
+	tracker := NewTracker(WithClock(clock), WithAutoExpire(time.Millisecond))
+	defer tracker.Close()
+	expired := make(chan OrderClientID, 1)
+	tracker.OnExpire(func(clid OrderClientID) { expired <- clid })
+
+	order := NewOrder("GTD", ExchangeBinance, "TEST", 1, 100)
+	order.TimeInForce, order.ExpiresAt = TifGTD, start.Add(time.Minute)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start); e != nil {
+		t.Fatal(e)
+	}
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case clid := <-expired:
+		t.Fatalf("Should not expire orders before their expiry time: %v", clid)
+	default:
+	}
+
+	elapsed.Store(int64(time.Minute))
+	select {
+	case clid := <-expired:
+		if clid != order.ClientID {
+			t.Errorf("Unexpected expired order: %v", clid)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Should expire orders in the background")
+	}
+}
+
+func TestTracker_WithCoalescedNotifications(t *testing.T) {
+	// The interval is long enough for changes to be delivered only by Close
+	tracker := NewTracker(WithCoalescedNotifications(time.Hour))
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	type change struct {
+		clid     OrderClientID
+		from, to OrderStatus
+	}
+	var changes []change
+	tracker.OnStatusChange(func(clid OrderClientID, from, to OrderStatus) {
+		changes = append(changes, change{clid, from, to})
+	})
+
+	for _, clid := range []OrderClientID{"PLACED", "FILLED", "MOVING"} {
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 100)); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(clid, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderFilled("FILLED", now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving("MOVING"); e != nil {
+		t.Fatal(e)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Should not deliver changes before the interval: %v", changes)
+	}
+	if e := tracker.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	want := []change{
+		{"FILLED", OrderUnplaced, OrderFilled},
+		{"MOVING", OrderUnplaced, OrderModifying},
+		{"PLACED", OrderUnplaced, OrderPlaced},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("Should deliver only the latest status of every order on close: %v", changes)
+	}
+}
+
+func TestTracker_Close(t *testing.T) {
+	tracker := NewTracker(WithAutoPurge(time.Millisecond, 0))
+	if e := tracker.OrderPlacing(NewOrder("ORDER", ExchangeBinance, "TEST", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	fills := tracker.SubscribeFills()
+	if e := tracker.Close(); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.Close(); e != nil {
+		t.Errorf("Should close twice: %v", e)
+	}
+
+	if _, ok := <-fills; ok {
+		t.Error("Should close fill subscriptions")
+	}
+	if _, ok := <-tracker.SubscribeFills(); ok {
+		t.Error("Should return closed subscription after close")
+	}
+	tracker.UnsubscribeFills(fills)
+	if e := tracker.OrderPlacing(NewOrder("ANOTHER", ExchangeBinance, "TEST", 1, 100)); !errors.Is(e, ErrTrackerClosed) {
+		t.Errorf("Should not place orders after close: %v", e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed("ORDER", time.Now()); !errors.Is(e, ErrTrackerClosed) {
+		t.Errorf("Should not confirm orders after close: %v", e)
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "TEST", 99, 101); !errors.Is(e, ErrTrackerClosed) {
+		t.Errorf("Should not push quotes after close: %v", e)
+	}
+	if got := tracker.CancelAll(); got != nil {
+		t.Errorf("Should not cancel orders after close: %v", got)
+	}
+	snapshot, e := tracker.MarshalBinary()
+	if e != nil {
+		t.Fatal(e)
+	}
+	_, disconnectErr := tracker.MarkExchangeDisconnected(ExchangeBinance)
+	calls := map[string]error{
+		"Reset":                    tracker.Reset(),
+		"Halt":                     tracker.Halt("closed"),
+		"Unhalt":                   tracker.Unhalt(),
+		"RegisterSymbol":           tracker.RegisterSymbol(ExchangeBinance, "TEST", SymbolSpec{TickSize: 1}),
+		"MarkExchangeDisconnected": disconnectErr,
+		"MarkExchangeReconnected":  tracker.MarkExchangeReconnected(ExchangeBinance),
+		"UnmarshalBinary":          tracker.UnmarshalBinary(snapshot),
+	}
+	for name, e := range calls {
+		if !errors.Is(e, ErrTrackerClosed) {
+			t.Errorf("%s should fail after close: %v", name, e)
+		}
+	}
+	if halted, _ := tracker.IsHalted(); halted || !tracker.IsExchangeConnected(ExchangeBinance) {
+		t.Error("Should not change the state after close")
+	}
+	if _, ok := tracker.GetSymbolSpec(ExchangeBinance, "TEST"); ok {
+		t.Error("Should not register symbols after close")
+	}
+	if status, e := tracker.GetOrderStatusString("ORDER"); e != nil || status != "Placing" {
+		t.Errorf("Should keep queries working after close: %q, %v", status, e)
+	}
+	if e := NewTracker().Close(); e != nil {
+		t.Errorf("Should close tracker without background goroutines: %v", e)
+	}
+}
+
+func TestTracker_CloseLeaksNoGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	trackers := make([]*Tracker, 10)
+	for i := range trackers {
+		trackers[i] = NewTracker(WithAutoPurge(time.Millisecond, time.Minute))
+	}
+	if runtime.NumGoroutine() < before+len(trackers) {
+		t.Fatal("Should start purging goroutines")
+	}
+	for _, tracker := range trackers {
+		if e := tracker.Close(); e != nil {
+			t.Fatal(e)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("Should stop goroutines on close: %d > %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// fillSubscriptionBuffer is the capacity of channels returned by SubscribeFills.
+const fillSubscriptionBuffer = 1024
+
+// FillEvent describes a fill applied to an order.
+type FillEvent struct {
+	ClientID OrderClientID
+	Time     time.Time
+	Amount   uint64
+	Price    Price
+}
+
+// SubscribeFills returns a channel receiving a FillEvent for every applied fill.
+// The channel is buffered, if the consumer is slow and the buffer is full,
+// new events are dropped for this subscriber instead of blocking the tracker.
+// Events are sent after the guard is released, in the order fills were applied.
+// Call UnsubscribeFills when the channel is not needed anymore.
+// The channel is closed by Close, a channel returned after Close is already closed.
+func (t *Tracker) SubscribeFills() <-chan FillEvent {
+	t.notifyGuard.Lock()
+	defer t.notifyGuard.Unlock()
+
+	subscription := make(chan FillEvent, fillSubscriptionBuffer)
+	if t.subscriptionsClosed {
+		close(subscription)
+		return subscription
+	}
+	t.fillSubscriptions = append(t.fillSubscriptions, subscription)
+	return subscription
+}
+
+// UnsubscribeFills stops sending events to the channel returned by SubscribeFills and closes it.
+// Unknown channels are ignored.
+func (t *Tracker) UnsubscribeFills(subscription <-chan FillEvent) {
+	t.notifyGuard.Lock()
+	defer t.notifyGuard.Unlock()
+
+	for i, s := range t.fillSubscriptions {
+		if s == subscription {
+			t.fillSubscriptions = slices.Delete(t.fillSubscriptions, i, i+1)
+			close(s)
+			return
+		}
+	}
+}
+
+// rejection is a queued notification of OnReject handlers.
+type rejection struct {
+	clid   OrderClientID
+	reason string
+	from   OrderStatus
+}
+
+// OnReject registers a handler called after a placement, modification or cancellation
+// is rejected, with the rejection reason and the order status before the rejection.
+// Handlers are called in the order of registration outside the guard, so they may call
+// the tracker. Handlers of concurrent rejections may run concurrently.
+func (t *Tracker) OnReject(fn func(clid OrderClientID, reason string, priorStatus OrderStatus)) {
+	t.notifyGuard.Lock()
+	defer t.notifyGuard.Unlock()
+	t.rejectHandlers = append(t.rejectHandlers, fn)
+}
+
+// statusChange is a queued notification of OnStatusChange handlers.
+type statusChange struct {
+	clid OrderClientID
+	from OrderStatus
+	to   OrderStatus
+}
+
+// OnStatusChange registers a handler called after an order moves from one status to another.
+// Handlers are called in the order of registration outside the guard, so they may call
+// the tracker. Handlers of concurrent calls may run concurrently.
+// With WithCoalescedNotifications, changes of an order are coalesced and the handlers
+// are called at most once per order every interval, from the status before the first
+// coalesced change to the latest one, so intermediate statuses may be skipped.
+func (t *Tracker) OnStatusChange(fn func(clid OrderClientID, from, to OrderStatus)) {
+	t.notifyGuard.Lock()
+	defer t.notifyGuard.Unlock()
+	t.statusHandlers = append(t.statusHandlers, fn)
+}
+
+// coalesce merges the status changes into the ones waiting for the next flush,
+// keeping the status before the first change of every order, notifyGuard should be held.
+func (t *Tracker) coalesce(changes []statusChange) {
+	for _, change := range changes {
+		if pending, exists := t.coalesced[change.clid]; exists {
+			change.from = pending.from
+		}
+		t.coalesced[change.clid] = change
+	}
+}
+
+// flushCoalesced calls OnStatusChange handlers with the coalesced status changes
+// sorted by client ID. Orders returned to the status they had before are skipped.
+func (t *Tracker) flushCoalesced() {
+	t.notifyGuard.Lock()
+	coalesced := t.coalesced
+	t.coalesced = make(map[OrderClientID]statusChange)
+	statusHandlers := t.statusHandlers
+	t.notifyGuard.Unlock()
+
+	for _, clid := range slices.Sorted(maps.Keys(coalesced)) {
+		change := coalesced[clid]
+		if change.from == change.to {
+			continue
+		}
+		for _, handler := range statusHandlers {
+			handler(change.clid, change.from, change.to)
+		}
+	}
+}
+
+// OnExpire registers a handler called after an order moves into OrderExpired, whether it is
+// expired by ExpireOrders, the sweep started by WithAutoExpire or OrderExpired reported
+// by the exchange, for example to re-quote a fresh order. Handlers are called in the order
+// of registration outside the guard, so they may call the tracker.
+// Handlers of concurrent calls may run concurrently.
+func (t *Tracker) OnExpire(fn func(clid OrderClientID)) {
+	t.notifyGuard.Lock()
+	defer t.notifyGuard.Unlock()
+	t.expireHandlers = append(t.expireHandlers, fn)
+}
+
+// failure is a queued call of the error logger.
+type failure struct {
+	op   string
+	clid OrderClientID
+	err  error
+}
+
+// failed queues the error of the operation for the logger set with WithErrorLogger and returns it,
+// the guard should be held. Nil errors are not logged.
+func (t *Tracker) failed(op string, clid OrderClientID, err error) error {
+	if err != nil && t.errorLogger != nil {
+		t.pendingErrors = append(t.pendingErrors, failure{op: op, clid: clid, err: err})
+	}
+	return err
+}
+
+// unlock releases the guard and then delivers the queued notifications.
+// Channel notifications are delivered and status changes are coalesced holding notifyGuard
+// acquired before the guard is released, so concurrent calls deliver them in the order they were queued. Handlers are called
+// after notifyGuard is released, followed by the error logger.
+func (t *Tracker) unlock() {
+	if len(t.pendingFills) == 0 && len(t.pendingRejects) == 0 && len(t.pendingChanges) == 0 &&
+		len(t.pendingExpires) == 0 && len(t.pendingErrors) == 0 {
+		t.guard.Unlock()
+		return
+	}
+	fills, rejects, changes, failures := t.pendingFills, t.pendingRejects, t.pendingChanges, t.pendingErrors
+	expires := t.pendingExpires
+	t.pendingFills, t.pendingRejects, t.pendingChanges, t.pendingErrors = nil, nil, nil, nil
+	t.pendingExpires = nil
+	t.notifyGuard.Lock()
+	t.guard.Unlock()
+
+	for _, fill := range fills {
+		for _, subscription := range t.fillSubscriptions {
+			select {
+			case subscription <- fill:
+			default: // Slow consumer, drop
+			}
+		}
+	}
+	rejectHandlers, expireHandlers := t.rejectHandlers, t.expireHandlers
+	var statusHandlers []func(OrderClientID, OrderStatus, OrderStatus)
+	if t.coalesced != nil {
+		t.coalesce(changes)
+		changes = nil
+	} else {
+		statusHandlers = t.statusHandlers
+	}
+	t.notifyGuard.Unlock()
+
+	for _, reject := range rejects {
+		for _, handler := range rejectHandlers {
+			handler(reject.clid, reject.reason, reject.from)
+		}
+	}
+	for _, change := range changes {
+		for _, handler := range statusHandlers {
+			handler(change.clid, change.from, change.to)
+		}
+	}
+	for _, clid := range expires {
+		for _, handler := range expireHandlers {
+			handler(clid)
+		}
+	}
+	for _, failure := range failures {
+		t.errorLogger(failure.op, failure.clid, failure.err)
+	}
+}
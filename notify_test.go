@@ -0,0 +1,273 @@
+package orderstracker
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTracker_SubscribeFills(t *testing.T) {
+	tracker := NewTracker()
+	fills := tracker.SubscribeFills()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilledWithTradeID(order.ClientID, now, "T1", 2, 101); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilledWithTradeID(order.ClientID, now, "T1", 2, 101); e != nil {
+		t.Fatal(e)
+	}
+
+	want := []FillEvent{
+		{ClientID: order.ClientID, Time: now, Amount: 1, Price: 100},
+		{ClientID: order.ClientID, Time: now, Amount: 2, Price: 101},
+	}
+	for _, w := range want {
+		select {
+		case got := <-fills:
+			if got != w {
+				t.Errorf("Should receive %+v, got %+v", w, got)
+			}
+		default:
+			t.Fatalf("Should receive %+v", w)
+		}
+	}
+	select {
+	case got := <-fills:
+		t.Errorf("Should not receive duplicate fill %+v", got)
+	default:
+	}
+
+	tracker.UnsubscribeFills(fills)
+	if _, open := <-fills; open {
+		t.Error("Should close channel on unsubscribe")
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+}
+
+func TestTracker_SubscribeFillsDropsWhenFull(t *testing.T) {
+	tracker := NewTracker()
+	fills := tracker.SubscribeFills()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	for range fillSubscriptionBuffer + 10 {
+		if _, e := tracker.OrderFilled(order.ClientID, time.Now(), 1, 100); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if len(fills) != fillSubscriptionBuffer {
+		t.Errorf("Should drop fills beyond buffer: %d", len(fills))
+	}
+}
+
+func TestTracker_OnReject(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	type rejected struct {
+		clid   OrderClientID
+		reason string
+		prior  OrderStatus
+	}
+	var first, second []rejected
+	tracker.OnReject(func(clid OrderClientID, reason string, priorStatus OrderStatus) {
+		first = append(first, rejected{clid, reason, priorStatus})
+		// Handlers are called outside the guard
+		if priorStatus == OrderPlacing {
+			if _, e := tracker.GetOrder(clid); e != nil {
+				t.Error(e)
+			}
+		}
+	})
+	tracker.OnReject(func(clid OrderClientID, reason string, priorStatus OrderStatus) {
+		second = append(second, rejected{clid, reason, priorStatus})
+	})
+
+	placing := GenerateOrderWithSymbol("TEST")
+	canceling := GenerateOrderWithSymbol("TEST")
+	for _, order := range []Order{placing, canceling} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed(canceling.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(canceling.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected(placing.ClientID, now, "no funds"); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.RejectCancel(canceling.ClientID, now, "too late"); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.RejectCancel(canceling.ClientID, now, "too late"); e == nil {
+		t.Fatal("Should fail to reject twice")
+	}
+
+	want := []rejected{
+		{placing.ClientID, "no funds", OrderPlacing},
+		{canceling.ClientID, "too late", OrderCanceling},
+	}
+	if !slices.Equal(first, want) || !slices.Equal(second, want) {
+		t.Errorf("Should call every handler on rejections: %v, %v", first, second)
+	}
+}
+
+func TestTracker_OnStatusChange(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	type change struct {
+		clid     OrderClientID
+		from, to OrderStatus
+	}
+	var changes []change
+	tracker.OnStatusChange(func(clid OrderClientID, from, to OrderStatus) {
+		changes = append(changes, change{clid, from, to})
+		// Handlers are called outside the guard
+		if _, e := tracker.GetOrder(clid); e != nil {
+			t.Error(e)
+		}
+	})
+
+	order := NewOrder("ORDER", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 4, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 6, 100); e != nil {
+		t.Fatal(e)
+	}
+
+	want := []change{
+		{order.ClientID, OrderUnplaced, OrderPlacing},
+		{order.ClientID, OrderPlacing, OrderPlaced},
+		{order.ClientID, OrderPlaced, OrderFilled},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("Should notify every status change and only them: %v", changes)
+	}
+}
+
+func TestTracker_OnStatusChangeOfBulkCalls(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	var changed []OrderClientID
+	tracker.OnStatusChange(func(clid OrderClientID, from, to OrderStatus) {
+		if from == OrderPlaced {
+			changed = append(changed, clid)
+		}
+	})
+	expiring := NewOrder("EXPIRING", ExchangeBinance, "TEST", 1, 100)
+	expiring.TimeInForce, expiring.ExpiresAt = TifGTD, now
+	for _, order := range []Order{expiring, NewOrder("CANCELING", ExchangeBinance, "TEST", 1, 100)} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	tracker.ExpireOrders(now)
+	if !slices.Equal(changed, []OrderClientID{"EXPIRING"}) {
+		t.Errorf("Should notify changes of expired orders: %v", changed)
+	}
+	tracker.CancelAll()
+	if !slices.Equal(changed, []OrderClientID{"EXPIRING", "CANCELING"}) {
+		t.Errorf("Should notify changes of canceled orders: %v", changed)
+	}
+}
+
+func TestTracker_OnExpire(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	var expired []OrderClientID
+	tracker.OnExpire(func(clid OrderClientID) {
+		expired = append(expired, clid)
+		// Handlers are called outside the guard
+		if status, e := tracker.GetOrderStatus(clid, nil, nil); e != nil || status != OrderExpired {
+			t.Errorf("Unexpected status of expired order: %v, %v", status, e)
+		}
+	})
+
+	gtd := NewOrder("GTD", ExchangeBinance, "TEST", 1, 100)
+	gtd.TimeInForce, gtd.ExpiresAt = TifGTD, now.Add(time.Minute)
+	reported := NewOrder("REPORTED", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{gtd, reported} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	tracker.ExpireOrders(now)
+	if len(expired) != 0 {
+		t.Errorf("Should not notify before the order expires: %v", expired)
+	}
+	tracker.ExpireOrders(now.Add(time.Minute))
+	if _, e := tracker.OrderExpired(reported.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"GTD", "REPORTED"}; !slices.Equal(expired, want) {
+		t.Errorf("Should notify expired orders: %v", expired)
+	}
+}
+
+func TestTracker_WithErrorLogger(t *testing.T) {
+	type logged struct {
+		op   string
+		clid OrderClientID
+	}
+	var got []logged
+	var tracker *Tracker
+	tracker = NewTracker(WithValidation(), WithErrorLogger(func(op string, clid OrderClientID, err error) {
+		if err == nil {
+			t.Errorf("Should not log nil error of %s", op)
+		}
+		// The logger is called outside the guard, so the tracker can be used
+		tracker.GetOrdersCount()
+		got = append(got, logged{op: op, clid: clid})
+	}))
+	order := NewOrder("LOGGED", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(order); e == nil {
+		t.Error("Should fail to place order twice")
+	}
+	if e := tracker.OrderMoving(order.ClientID); e == nil {
+		t.Error("Should fail to move placing order")
+	}
+	if _, e := tracker.OrderFilled("unknown", time.Now(), 1, 100); e == nil {
+		t.Error("Should fail to fill unknown order")
+	}
+	if _, e := tracker.PushQuote(ExchangeNone, "TEST", 1, 2); e == nil {
+		t.Error("Should fail to push invalid quote")
+	}
+	want := []logged{
+		{"OrderPlacing", order.ClientID},
+		{"OrderMoving", order.ClientID},
+		{"OrderFilled", "unknown"},
+		{"PushQuote", ""},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Unexpected logged errors: %v != %v", got, want)
+	}
+}
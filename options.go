@@ -0,0 +1,194 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"time"
+)
+
+// Option configures a Tracker created by NewTracker.
+type Option func(*Tracker)
+
+// VWAPRounding defines how the aggregated fill price of execution reports is rounded.
+type VWAPRounding int
+
+const (
+	// VWAPTruncate truncates the aggregated price toward zero, it is the default.
+	VWAPTruncate VWAPRounding = iota
+	// VWAPRoundHalfUp rounds the aggregated price to the nearest integer, halves up.
+	VWAPRoundHalfUp
+)
+
+// WithVWAPRounding sets the rounding of the aggregated fill price of execution reports.
+// Truncation, the default, biases the average price of multiple fills downward.
+func WithVWAPRounding(rounding VWAPRounding) Option {
+	return func(t *Tracker) {
+		t.rounding = rounding
+	}
+}
+
+// WithClock sets the clock used to timestamp calls not taking the time as an argument
+// (OrderPlacing, OrderMoving, OrderCancelling and others). The default clock is time.Now.
+// It is mostly useful for deterministic tests and simulations.
+func WithClock(now func() time.Time) Option {
+	return func(t *Tracker) {
+		t.now = now
+	}
+}
+
+// WithValidation enables validation of input data.
+// Quotes pushed for ExchangeNone or an empty symbol are rejected with ErrInvalidQuote.
+// Orders with an empty client ID or symbol, ExchangeNone, zero amount, zero price of a limit order,
+// or GTD orders without expiration time are rejected with ErrInvalidOrder.
+func WithValidation() Option {
+	return func(t *Tracker) {
+		t.validation = true
+	}
+}
+
+// WithStrictFills rejects fills of orders in OrderUnplaced or OrderPlacing with ErrFillNotPlaced,
+// as such fills usually indicate a message routing bug. It should not be used with exchanges
+// sending fills ahead of the placement acknowledgment.
+func WithStrictFills() Option {
+	return func(t *Tracker) {
+		t.strictFills = true
+	}
+}
+
+// WithFillHistory keeps every fill of an order in its fill history, available in snapshots
+// and used by TurnoverWindow. Without it only the order totals and the latest execution report
+// are updated by fills, so the memory used by an order doesn't grow with the number of its fills.
+func WithFillHistory() Option {
+	return func(t *Tracker) {
+		t.fillHistory = true
+	}
+}
+
+// WithMaxOrders limits the number of active orders: OrderPlacing returns ErrTooManyOrders
+// while n orders are active. Orders in terminal states don't count toward the limit.
+// A non-positive n means no limit.
+func WithMaxOrders(n int) Option {
+	return func(t *Tracker) {
+		t.maxOrders = n
+	}
+}
+
+// WithInitialCapacity preallocates room for the given numbers of tracked orders and exchanges,
+// so placing up to that many orders doesn't grow the maps. The orders map of each symbol is
+// created with room for the same number of orders when its first order is placed.
+// The capacities are also used by Reset. Non-positive numbers keep the default capacities.
+func WithInitialCapacity(orders int, exchanges int) Option {
+	return func(t *Tracker) {
+		t.orderCapacity = max(orders, 0)
+		t.exchangeCapacity = max(exchanges, 0)
+	}
+}
+
+// WithMaxFillsPerOrder limits the number of fills kept per order by TrimHistory to the n most recent ones.
+// It is meaningful with WithFillHistory only.
+// A non-positive n means no limit.
+func WithMaxFillsPerOrder(n int) Option {
+	return func(t *Tracker) {
+		t.maxFills = n
+	}
+}
+
+// WithMoveThresholdBps makes PushQuote signal placed orders for repricing when their price deviates
+// from the mid price by more than bps basis points of it, see QuoteSignals. Zero, the default,
+// signals orders resting behind the best price by at most the spread instead.
+func WithMoveThresholdBps(bps uint64) Option {
+	return func(t *Tracker) {
+		t.moveBps = bps
+	}
+}
+
+// WithMoveThreshold is an alias of WithMoveThresholdBps.
+//
+// Deprecated: use WithMoveThresholdBps.
+func WithMoveThreshold(bps uint64) Option {
+	return WithMoveThresholdBps(bps)
+}
+
+// WithErrorLogger sets a function called with the operation name, the client ID and the error
+// whenever a mutating call fails, like a transition not allowed in the order status.
+// The client ID is empty for calls not related to an order such as PushQuote.
+// The function is called outside the guard, so it may call the tracker.
+// Context variants don't log errors of giving up waiting for the guard.
+func WithErrorLogger(fn func(op string, clid OrderClientID, err error)) Option {
+	return func(t *Tracker) {
+		t.errorLogger = fn
+	}
+}
+
+// WithAutoPurge starts a background goroutine calling PurgeCompleted every interval
+// to remove orders that entered a terminal state more than retain ago by the tracker clock.
+// Close must be called when the tracker is discarded to stop the goroutine.
+// A non-positive interval disables automatic purging.
+func WithAutoPurge(interval time.Duration, retain time.Duration) Option {
+	return func(t *Tracker) {
+		t.purgeInterval = interval
+		t.purgeRetain = retain
+	}
+}
+
+// WithAutoExpire starts a background goroutine calling ExpireOrders with the tracker clock
+// every interval, so expired orders are reported to OnExpire handlers without driving
+// the expiry manually. Close must be called when the tracker is discarded to stop the goroutine.
+// A non-positive interval disables automatic expiry.
+func WithAutoExpire(interval time.Duration) Option {
+	return func(t *Tracker) {
+		t.expireInterval = interval
+	}
+}
+
+// WithCoalescedNotifications coalesces the status changes of every order delivered
+// to OnStatusChange handlers into the latest status, delivered by a background goroutine
+// at most once per interval. Intermediate statuses may be skipped, and an order
+// returning to its status before the interval is not reported at all.
+// Close must be called when the tracker is discarded to stop the goroutine.
+// A non-positive interval disables coalescing.
+func WithCoalescedNotifications(interval time.Duration) Option {
+	return func(t *Tracker) {
+		t.coalesceInterval = interval
+	}
+}
+
+// WithExpvar publishes the tracker Stats as an expvar variable with the given name.
+// As with expvar.Publish, the name should be unique within the process, otherwise it panics.
+func WithExpvar(name string) Option {
+	return func(t *Tracker) {
+		expvar.Publish(name, expvar.Func(func() any {
+			return t.Stats()
+		}))
+	}
+}
+
+// WithEventLog writes every successful mutating call as an Event encoded in a JSON line to the writer.
+// The events can be read back with ReadEvents and replayed with ReplayEvents.
+// A failure to write an event halts the tracker, the call whose event failed is still applied.
+func WithEventLog(w io.Writer) Option {
+	return func(t *Tracker) {
+		if t.events == nil {
+			t.events = &eventLog{}
+		}
+		t.events.encoder = json.NewEncoder(w)
+	}
+}
+
+// WithEventRing keeps the last capacity events of successful mutating calls in memory,
+// they are available with Events. A non-positive capacity disables the ring.
+func WithEventRing(capacity int) Option {
+	return func(t *Tracker) {
+		if capacity <= 0 {
+			return
+		}
+		if t.events == nil {
+			t.events = &eventLog{}
+		}
+		t.events.ring = make([]Event, capacity)
+	}
+}
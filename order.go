@@ -4,8 +4,10 @@
 package orderstracker
 
 import (
+	"fmt"
 	"math/rand/v2"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -19,6 +21,8 @@ const (
 	OrderModifying
 	OrderCanceling
 	OrderFilled
+	OrderExpired
+	OrderUnknown
 )
 
 func (o OrderStatus) String() string {
@@ -35,14 +39,35 @@ func (o OrderStatus) String() string {
 		return "Canceling"
 	case OrderFilled:
 		return "Filled"
-	default:
+	case OrderExpired:
+		return "Expired"
+	case OrderUnknown:
 		return "Unknown"
+	default:
+		return fmt.Sprintf("OrderStatus(%d)", int(o))
+	}
+}
+
+// isValid reports whether the status is one of the defined statuses.
+func (o OrderStatus) isValid() bool {
+	return o >= OrderUnplaced && o <= OrderUnknown
+}
+
+// isActive reports whether an order in the status may still be live on the exchange.
+func (o OrderStatus) isActive() bool {
+	switch o {
+	case OrderPlacing, OrderPlaced, OrderModifying, OrderCanceling, OrderUnknown:
+		return true
+	default:
+		return false
 	}
 }
 
 type OrderClientID string
 type ExchangeID int
 
+// Built-in exchanges, ExchangeCount is the number of them.
+// Other exchanges are added at runtime with RegisterExchange.
 const (
 	ExchangeNone ExchangeID = iota
 	ExchangeBinance
@@ -50,44 +75,214 @@ const (
 	ExchangeCount
 )
 
+// exchangeRegistry maps exchange IDs to their names, an ID is an index in names.
+var exchangeRegistry = struct {
+	guard sync.RWMutex
+	names []string
+}{
+	names: []string{"None", "Binance", "Kraken"},
+}
+
+// RegisterExchange returns the ExchangeID assigned to the exchange with the given name.
+// A new ID is assigned if the name is not registered yet, so registering the same name
+// again returns the same ID. Built-in exchanges are registered under their constant names.
+// It is safe to call RegisterExchange concurrently.
+func RegisterExchange(name string) ExchangeID {
+	exchangeRegistry.guard.Lock()
+	defer exchangeRegistry.guard.Unlock()
+
+	for i, registered := range exchangeRegistry.names {
+		if registered == name {
+			return ExchangeID(i)
+		}
+	}
+	exchangeRegistry.names = append(exchangeRegistry.names, name)
+	return ExchangeID(len(exchangeRegistry.names) - 1)
+}
+
 func (eid ExchangeID) String() string {
-	switch eid {
-	case ExchangeNone:
+	exchangeRegistry.guard.RLock()
+	defer exchangeRegistry.guard.RUnlock()
+
+	if eid < 0 || int(eid) >= len(exchangeRegistry.names) {
+		return "Unknown"
+	}
+	return exchangeRegistry.names[eid]
+}
+
+type SymbolID string
+
+// TimeInForce defines how long an order remains active.
+type TimeInForce int
+
+const (
+	TifGTC TimeInForce = iota // Good till canceled
+	TifIOC                    // Immediate or cancel
+	TifGTD                    // Good till date, see Order.ExpiresAt
+)
+
+func (tif TimeInForce) String() string {
+	switch tif {
+	case TifGTC:
+		return "GTC"
+	case TifIOC:
+		return "IOC"
+	case TifGTD:
+		return "GTD"
+	default:
+		return "Unknown"
+	}
+}
+
+// OrderSide is the direction of an order.
+type OrderSide int
+
+const (
+	SideNone OrderSide = iota
+	SideBuy
+	SideSell
+)
+
+func (os OrderSide) String() string {
+	switch os {
+	case SideNone:
 		return "None"
-	case ExchangeBinance:
-		return "Binance"
-	case ExchangeKraken:
-		return "Kraken"
+	case SideBuy:
+		return "Buy"
+	case SideSell:
+		return "Sell"
 	default:
 		return "Unknown"
 	}
 }
 
-type SymbolID string
+// OrderType distinguishes resting limit orders from market orders executed immediately.
+type OrderType int
+
+const (
+	TypeLimit OrderType = iota
+	TypeMarket
+)
 
+func (ot OrderType) String() string {
+	switch ot {
+	case TypeLimit:
+		return "Limit"
+	case TypeMarket:
+		return "Market"
+	default:
+		return "Unknown"
+	}
+}
+
+// Order describes an order sent to an exchange.
+// DisplayQuantity is the visible slice of an iceberg order, zero for orders displayed entirely.
+// Tag groups orders of a strategy sharing the tracker with others, it is empty for untagged orders.
 type Order struct {
-	ClientID OrderClientID
-	Exchange ExchangeID
-	Symbol   SymbolID
-	Amount   uint64
-	Price    uint64
+	ClientID        OrderClientID
+	Exchange        ExchangeID
+	Symbol          SymbolID
+	Side            OrderSide
+	Type            OrderType
+	Amount          uint64
+	Price           Price
+	TimeInForce     TimeInForce
+	ExpiresAt       time.Time
+	DisplayQuantity uint64
+	Tag             string
 }
 
-func NewOrder(clid OrderClientID, exchange ExchangeID, symbol SymbolID, amount uint64, price uint64) Order {
+// NewOrder creates a limit order.
+func NewOrder(clid OrderClientID, exchange ExchangeID, symbol SymbolID, amount uint64, price Price) Order {
 	return Order{
 		ClientID: clid,
 		Exchange: exchange,
 		Symbol:   symbol,
+		Type:     TypeLimit,
 		Amount:   amount,
 		Price:    price,
 	}
 }
 
-var clientIDCounter atomic.Uint32
+// NewMarketOrder creates a market order, it has no price.
+func NewMarketOrder(clid OrderClientID, exchange ExchangeID, symbol SymbolID, amount uint64) Order {
+	return Order{
+		ClientID: clid,
+		Exchange: exchange,
+		Symbol:   symbol,
+		Type:     TypeMarket,
+		Amount:   amount,
+	}
+}
+
+// WithTag returns a copy of the order with the given tag, for example NewOrder(...).WithTag("mm").
+func (o Order) WithTag(tag string) Order {
+	o.Tag = tag
+	return o
+}
+
+// validate returns ErrInvalidOrder describing the first malformed field of the order.
+func (o Order) validate() error {
+	switch {
+	case o.ClientID == "":
+		return fmt.Errorf("%w: client id is empty", ErrInvalidOrder)
+	case o.Exchange == ExchangeNone:
+		return fmt.Errorf("%w: exchange is not set (clid %v)", ErrInvalidOrder, o.ClientID)
+	case o.Symbol == "":
+		return fmt.Errorf("%w: symbol is empty (clid %v)", ErrInvalidOrder, o.ClientID)
+	case o.Amount == 0:
+		return fmt.Errorf("%w: amount is zero (clid %v)", ErrInvalidOrder, o.ClientID)
+	case o.Price == 0 && o.Type != TypeMarket:
+		return fmt.Errorf("%w: price is zero (clid %v)", ErrInvalidOrder, o.ClientID)
+	case o.TimeInForce == TifGTD && o.ExpiresAt.IsZero():
+		return fmt.Errorf("%w: GTD order without expiration time (clid %v)", ErrInvalidOrder, o.ClientID)
+	default:
+		return nil
+	}
+}
+
+// Notional returns the cash value of the order (Amount × Price).
+// The value saturates at math.MaxUint64 instead of overflowing.
+func (o Order) Notional() uint64 {
+	return mul64(o.Amount, uint64(o.Price)).saturated()
+}
+
+// ClientIDGenerator generates unique client order IDs from a clock and the last generated ID.
+// An ID is the hex encoded number of milliseconds since the Unix epoch shifted left by 16 bits,
+// or the last ID plus one if it is not greater. So IDs are sortable by generation order
+// and never repeat within a generator, even for bursts of more than 65536 IDs per millisecond
+// or when the clock goes backwards. It is safe for concurrent use.
+type ClientIDGenerator struct {
+	clock func() time.Time
+	last  atomic.Uint64
+}
+
+// NewClientIDGenerator creates a generator taking the time from the given clock,
+// time.Now if the clock is nil. A fixed clock makes generated IDs predictable in tests.
+func NewClientIDGenerator(clock func() time.Time) *ClientIDGenerator {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &ClientIDGenerator{clock: clock}
+}
+
+// Next returns the next client order ID.
+func (g *ClientIDGenerator) Next() OrderClientID {
+	base := uint64(g.clock().UnixMilli()) << 16
+	for {
+		last := g.last.Load()
+		id := max(base, last+1)
+		if g.last.CompareAndSwap(last, id) {
+			return OrderClientID(strconv.FormatUint(id, 16))
+		}
+	}
+}
+
+var defaultClientIDGenerator = NewClientIDGenerator(time.Now)
 
+// GenerateClientOrderID returns the next client order ID of the default generator using the real clock.
 func GenerateClientOrderID() OrderClientID {
-	id := uint64(time.Now().Unix()<<16) | uint64(clientIDCounter.Add(1)&0xFFFF)
-	return OrderClientID(strconv.FormatUint(id, 16))
+	return defaultClientIDGenerator.Next()
 }
 
 func GenerateOrderWithSymbol(symbol SymbolID) Order {
@@ -95,7 +290,8 @@ func GenerateOrderWithSymbol(symbol SymbolID) Order {
 		ClientID: GenerateClientOrderID(),
 		Exchange: ExchangeID(rand.IntN(int(ExchangeCount)-1) + 1),
 		Symbol:   symbol,
+		Side:     OrderSide(rand.IntN(2) + 1),
 		Amount:   rand.Uint64N(1000000000) + 1,
-		Price:    rand.Uint64N(1000000) + 1,
+		Price:    Price(rand.Uint64N(1000000) + 1),
 	}
 }
@@ -1,6 +1,11 @@
 package orderstracker
 
-import "testing"
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
 
 func Test_GenerateClientOrderID(t *testing.T) {
 	got := GenerateClientOrderID()
@@ -13,6 +18,56 @@ func Test_GenerateClientOrderID(t *testing.T) {
 	}
 }
 
+func Test_ClientIDGenerator(t *testing.T) {
+	clock := func() time.Time { return time.Unix(1, 0) }
+	generator := NewClientIDGenerator(clock)
+	want := []OrderClientID{"3e80000", "3e80001", "3e80002"}
+	for _, w := range want {
+		if got := generator.Next(); got != w {
+			t.Errorf("Unexpected id: %v != %v", got, w)
+		}
+	}
+	if got := NewClientIDGenerator(clock).Next(); got != want[0] {
+		t.Errorf("Generators with the same clock should produce the same ids: %v", got)
+	}
+}
+
+func Test_ClientIDGeneratorBurst(t *testing.T) {
+	generator := NewClientIDGenerator(func() time.Time { return time.Unix(1, 0) })
+	const count = 200000
+	seen := make(map[OrderClientID]struct{}, count)
+	for range count {
+		seen[generator.Next()] = struct{}{}
+	}
+	if len(seen) != count {
+		t.Errorf("Should generate unique ids within a millisecond: %d duplicates", count-len(seen))
+	}
+}
+
+func Test_GenerateClientOrderIDConcurrent(t *testing.T) {
+	const goroutines, count = 8, 20000
+	ids := make(chan OrderClientID, goroutines*count)
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range count {
+				ids <- GenerateClientOrderID()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[OrderClientID]struct{}, goroutines*count)
+	for id := range ids {
+		seen[id] = struct{}{}
+	}
+	if len(seen) != goroutines*count {
+		t.Errorf("Should generate unique ids concurrently: %d duplicates", goroutines*count-len(seen))
+	}
+}
+
 func Test_GenerateOrderWithSymbol(t *testing.T) {
 	wantSymbol := SymbolID("TEST")
 	got := GenerateOrderWithSymbol(wantSymbol)
@@ -25,6 +80,9 @@ func Test_GenerateOrderWithSymbol(t *testing.T) {
 	if got.Exchange >= ExchangeCount {
 		t.Error("Should not return order with invalid exchange")
 	}
+	if got.Side != SideBuy && got.Side != SideSell {
+		t.Errorf("Should return order with valid side: %v", got.Side)
+	}
 	if got.Symbol != wantSymbol {
 		t.Errorf("Should have specified symbol: %v != %v", got.Symbol, wantSymbol)
 	}
@@ -35,3 +93,56 @@ func Test_GenerateOrderWithSymbol(t *testing.T) {
 		t.Error("Amount should not be zero")
 	}
 }
+
+func Test_RegisterExchange(t *testing.T) {
+	if got := RegisterExchange("Binance"); got != ExchangeBinance {
+		t.Errorf("Should return built-in exchange: %v", got)
+	}
+	okx := RegisterExchange("OKX")
+	if okx < ExchangeCount {
+		t.Errorf("Should assign new id to new exchange: %d", okx)
+	}
+	if okx.String() != "OKX" {
+		t.Errorf("Should return registered name: %v", okx)
+	}
+	if got := RegisterExchange("OKX"); got != okx {
+		t.Errorf("Should return the same id for the same name: %v != %v", got, okx)
+	}
+	if got := RegisterExchange("Bybit"); got == okx {
+		t.Error("Should assign distinct ids to distinct exchanges")
+	}
+	if ExchangeKraken.String() != "Kraken" {
+		t.Error("Should keep built-in exchange names")
+	}
+	if ExchangeID(-1).String() != "Unknown" || ExchangeID(1<<20).String() != "Unknown" {
+		t.Error("Should return 'Unknown' for unregistered exchanges")
+	}
+}
+
+func TestOrderStatus_String(t *testing.T) {
+	if OrderUnknown.String() != "Unknown" {
+		t.Errorf("Unexpected name of OrderUnknown: %v", OrderUnknown)
+	}
+	if got := OrderStatus(42).String(); got != "OrderStatus(42)" {
+		t.Errorf("Should distinguish undefined statuses from OrderUnknown: %v", got)
+	}
+}
+
+func TestOrder_Notional(t *testing.T) {
+	if got := NewOrder("1", ExchangeBinance, "TEST", 20, 300).Notional(); got != 6000 {
+		t.Errorf("Unexpected notional: %v", got)
+	}
+	if got := NewOrder("1", ExchangeBinance, "TEST", math.MaxUint64/2, 3).Notional(); got != math.MaxUint64 {
+		t.Errorf("Should saturate on overflow: %v", got)
+	}
+}
+
+func Test_NewMarketOrder(t *testing.T) {
+	got := NewMarketOrder("1", ExchangeBinance, "TEST", 10)
+	if got.Type != TypeMarket || got.Price != 0 || got.Amount != 10 {
+		t.Errorf("Unexpected market order: %+v", got)
+	}
+	if NewOrder("1", ExchangeBinance, "TEST", 10, 1).Type != TypeLimit {
+		t.Error("Should create limit order by default")
+	}
+}
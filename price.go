@@ -0,0 +1,94 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Price is a raw price: an integer number of the smallest price units of a symbol.
+// The number of decimal digits of raw prices is the PriceScale of the symbol spec,
+// so a raw price p means p / 10^PriceScale. Price is encoded like uint64,
+// so encoded orders and reports are the same as with raw integer prices.
+// Prices of orders, fills, reports, quotes and events are Price, while values derived
+// from prices like notionals, price improvement or slippage are plain integers.
+type Price uint64
+
+// Raw returns the price as a raw integer.
+func (p Price) Raw() uint64 {
+	return uint64(p)
+}
+
+// Format returns the price as a decimal with scale digits after the point,
+// for example Price(12345).Format(2) is "123.45".
+func (p Price) Format(scale uint8) string {
+	digits := strconv.FormatUint(uint64(p), 10)
+	if scale == 0 {
+		return digits
+	}
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(scale)
+	return digits[:point] + "." + digits[point:]
+}
+
+// Mid returns the price halfway between p and q rounded down.
+func (p Price) Mid(q Price) Price {
+	return Price(mid(uint64(p), uint64(q)))
+}
+
+// Distance returns the absolute difference between p and q.
+func (p Price) Distance(q Price) uint64 {
+	if p < q {
+		return uint64(q - p)
+	}
+	return uint64(p - q)
+}
+
+// ParsePrice parses a decimal with at most scale digits after the point, like "123.45",
+// into a raw price. Returns ErrInvalidPrice if the string is not an unsigned decimal,
+// has more digits after the point than the scale or the raw price overflows uint64.
+func ParsePrice(s string, scale uint8) (Price, error) {
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	switch {
+	case integer == "" || hasPoint && fraction == "":
+		return 0, fmt.Errorf("%w: malformed decimal %q", ErrInvalidPrice, s)
+	case len(fraction) > int(scale):
+		return 0, fmt.Errorf("%w: more than %d digits after the point %q", ErrInvalidPrice, scale, s)
+	}
+	raw, err := strconv.ParseUint(integer+fraction+strings.Repeat("0", int(scale)-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %v", ErrInvalidPrice, s, err)
+	}
+	return Price(raw), nil
+}
+
+// FormatSymbolPrice formats the price with the PriceScale of the symbol on the exchange.
+// Returns an error if the symbol is not registered with RegisterSymbol.
+func (t *Tracker) FormatSymbolPrice(exchange ExchangeID, symbol SymbolID, price Price) (string, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	spec, exists := t.specs[exchange][symbol]
+	if !exists {
+		return "", fmt.Errorf("symbol is not registered (exchange %v, symbol %v)", exchange, symbol)
+	}
+	return price.Format(spec.PriceScale), nil
+}
+
+// ParseSymbolPrice parses the decimal price with the PriceScale of the symbol on the exchange
+// like ParsePrice. Returns an error if the symbol is not registered with RegisterSymbol.
+func (t *Tracker) ParseSymbolPrice(exchange ExchangeID, symbol SymbolID, s string) (Price, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	spec, exists := t.specs[exchange][symbol]
+	if !exists {
+		return 0, fmt.Errorf("symbol is not registered (exchange %v, symbol %v)", exchange, symbol)
+	}
+	return ParsePrice(s, spec.PriceScale)
+}
@@ -0,0 +1,101 @@
+package orderstracker
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func Test_PriceFormat(t *testing.T) {
+	tests := []struct {
+		price Price
+		scale uint8
+		want  string
+	}{
+		{12345, 2, "123.45"},
+		{12345, 0, "12345"},
+		{5, 3, "0.005"},
+		{0, 2, "0.00"},
+		{100, 2, "1.00"},
+		{18446744073709551615, 19, "1.8446744073709551615"},
+	}
+	for _, test := range tests {
+		if got := test.price.Format(test.scale); got != test.want {
+			t.Errorf("Unexpected format of %d with scale %d: %s != %s", test.price, test.scale, got, test.want)
+		}
+	}
+}
+
+func Test_ParsePrice(t *testing.T) {
+	tests := []struct {
+		s     string
+		scale uint8
+		want  Price
+	}{
+		{"123.45", 2, 12345},
+		{"123.4", 2, 12340},
+		{"123", 2, 12300},
+		{"0.005", 3, 5},
+		{"18446744073709551615", 0, 18446744073709551615},
+	}
+	for _, test := range tests {
+		got, e := ParsePrice(test.s, test.scale)
+		if e != nil || got != test.want {
+			t.Errorf("Unexpected price of %q with scale %d: %v, %v", test.s, test.scale, got, e)
+		}
+		if back, e := ParsePrice(got.Format(test.scale), test.scale); e != nil || back != got {
+			t.Errorf("Should parse formatted price %v: %v, %v", got, back, e)
+		}
+	}
+	for _, s := range []string{"", ".5", "1.", "1.234", "-1", "+1", "1e3", "1_000", "18446744073709551616", "1.2.3"} {
+		if _, e := ParsePrice(s, 2); !errors.Is(e, ErrInvalidPrice) {
+			t.Errorf("Should not parse %q: %v", s, e)
+		}
+	}
+}
+
+func Test_PriceArithmetic(t *testing.T) {
+	if got := Price(99).Mid(101); got != 100 {
+		t.Errorf("Unexpected mid: %v", got)
+	}
+	if got := Price(18446744073709551615).Mid(18446744073709551615); got != 18446744073709551615 {
+		t.Errorf("Should not overflow computing mid: %v", got)
+	}
+	if Price(99).Distance(101) != 2 || Price(101).Distance(99) != 2 {
+		t.Error("Unexpected distance")
+	}
+	if Price(42).Raw() != 42 {
+		t.Error("Unexpected raw price")
+	}
+}
+
+func Test_PriceEncoding(t *testing.T) {
+	data, e := json.Marshal(NewOrder("ORDER", ExchangeBinance, "TEST", 1, 12345))
+	if e != nil {
+		t.Fatal(e)
+	}
+	var raw struct{ Price uint64 }
+	if e := json.Unmarshal(data, &raw); e != nil || raw.Price != 12345 {
+		t.Errorf("Should encode price as raw integer: %s", data)
+	}
+}
+
+func TestTracker_SymbolPrice(t *testing.T) {
+	tracker := NewTracker()
+	if _, e := tracker.FormatSymbolPrice(ExchangeBinance, "BTCUSDT", 1); e == nil {
+		t.Error("Should not format price of unregistered symbol")
+	}
+	if _, e := tracker.ParseSymbolPrice(ExchangeBinance, "BTCUSDT", "1"); e == nil {
+		t.Error("Should not parse price of unregistered symbol")
+	}
+	tracker.RegisterSymbol(ExchangeBinance, "BTCUSDT", SymbolSpec{PriceScale: 2, TickSize: 1})
+	if s, e := tracker.FormatSymbolPrice(ExchangeBinance, "BTCUSDT", 6500012); e != nil || s != "65000.12" {
+		t.Errorf("Unexpected formatted price: %q, %v", s, e)
+	}
+	if p, e := tracker.ParseSymbolPrice(ExchangeBinance, "BTCUSDT", "65000.1"); e != nil || p != 6500010 {
+		t.Errorf("Unexpected parsed price: %v, %v", p, e)
+	}
+	if _, e := tracker.ParseSymbolPrice(ExchangeBinance, "BTCUSDT", "65000.123"); !errors.Is(e, ErrInvalidPrice) {
+		t.Errorf("Should not parse price finer than the scale: %v", e)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+// Package promexporter exports orderstracker metrics to Prometheus.
+// It lives in a separate module, so users of the tracker not needing Prometheus
+// don't depend on the Prometheus client.
+//
+// Exported metrics:
+//   - orderstracker_active_orders{status} -- gauge of orders in the active status
+//     (Placing, Placed, Modifying, Canceling or Unknown);
+//   - orderstracker_placed_total -- counter of confirmed placements;
+//   - orderstracker_filled_total -- counter of applied fills;
+//   - orderstracker_rejected_total -- counter of rejected placements, modifications and cancellations;
+//   - orderstracker_canceled_total -- counter of confirmed cancellations.
+package promexporter
+
+import (
+	"github.com/ortfero/orderstracker"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var activeStatuses = []orderstracker.OrderStatus{
+	orderstracker.OrderPlacing,
+	orderstracker.OrderPlaced,
+	orderstracker.OrderModifying,
+	orderstracker.OrderCanceling,
+	orderstracker.OrderUnknown,
+}
+
+var (
+	activeOrdersDesc = prometheus.NewDesc("orderstracker_active_orders",
+		"Number of orders in the active status.", []string{"status"}, nil)
+	placedDesc = prometheus.NewDesc("orderstracker_placed_total",
+		"Number of confirmed order placements.", nil, nil)
+	filledDesc = prometheus.NewDesc("orderstracker_filled_total",
+		"Number of applied order fills.", nil, nil)
+	rejectedDesc = prometheus.NewDesc("orderstracker_rejected_total",
+		"Number of rejected order placements, modifications and cancellations.", nil, nil)
+	canceledDesc = prometheus.NewDesc("orderstracker_canceled_total",
+		"Number of confirmed order cancellations.", nil, nil)
+)
+
+// Collector is a prometheus.Collector reading the tracker metrics on every scrape.
+type Collector struct {
+	tracker *orderstracker.Tracker
+}
+
+// NewCollector creates a collector of the tracker metrics.
+func NewCollector(tracker *orderstracker.Tracker) *Collector {
+	return &Collector{tracker: tracker}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- activeOrdersDesc
+	descs <- placedDesc
+	descs <- filledDesc
+	descs <- rejectedDesc
+	descs <- canceledDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
+	counts := c.tracker.GetOrdersCountByStatus()
+	for _, status := range activeStatuses {
+		metrics <- prometheus.MustNewConstMetric(activeOrdersDesc, prometheus.GaugeValue,
+			float64(counts[status]), status.String())
+	}
+
+	stats := c.tracker.Stats()
+	metrics <- prometheus.MustNewConstMetric(placedDesc, prometheus.CounterValue, float64(stats.Placed))
+	metrics <- prometheus.MustNewConstMetric(filledDesc, prometheus.CounterValue, float64(stats.Filled))
+	metrics <- prometheus.MustNewConstMetric(rejectedDesc, prometheus.CounterValue, float64(stats.Rejected))
+	metrics <- prometheus.MustNewConstMetric(canceledDesc, prometheus.CounterValue, float64(stats.Canceled))
+}
+
+// WithRegisterer registers the collector of the tracker metrics with the registerer.
+// As with prometheus.Registerer.MustRegister, it panics if the metrics are already registered.
+func WithRegisterer(registerer prometheus.Registerer) orderstracker.Option {
+	return func(t *orderstracker.Tracker) {
+		registerer.MustRegister(NewCollector(t))
+	}
+}
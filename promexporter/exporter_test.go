@@ -0,0 +1,51 @@
+package promexporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ortfero/orderstracker"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tracker := orderstracker.NewTracker(WithRegisterer(registry))
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	placed := orderstracker.GenerateOrderWithSymbol("TEST")
+	rejected := orderstracker.GenerateOrderWithSymbol("TEST")
+	for _, order := range []orderstracker.Order{placed, rejected} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed(placed.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected(rejected.ClientID, now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	want := `
+# HELP orderstracker_active_orders Number of orders in the active status.
+# TYPE orderstracker_active_orders gauge
+orderstracker_active_orders{status="Canceling"} 0
+orderstracker_active_orders{status="Modifying"} 0
+orderstracker_active_orders{status="Placed"} 1
+orderstracker_active_orders{status="Placing"} 0
+orderstracker_active_orders{status="Unknown"} 0
+# HELP orderstracker_placed_total Number of confirmed order placements.
+# TYPE orderstracker_placed_total counter
+orderstracker_placed_total 1
+# HELP orderstracker_rejected_total Number of rejected order placements, modifications and cancellations.
+# TYPE orderstracker_rejected_total counter
+orderstracker_rejected_total 1
+`
+	e := testutil.GatherAndCompare(registry, strings.NewReader(want),
+		"orderstracker_active_orders", "orderstracker_placed_total", "orderstracker_rejected_total")
+	if e != nil {
+		t.Error(e)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "slices"
+
+// QuoteSignals categorizes the placed orders of a symbol against its latest quote.
+// Crossed orders are through the spread and likely to fill or on the wrong side of the mid price,
+// buys above it and sells below it, exposed to adverse selection. Reprice orders rest behind
+// the best price of their side by at most the spread and FarFromMarket orders rest
+// behind it by more than the spread. Orders at or inside the spread are not signaled,
+// as are orders with a pending modification or cancellation. Client IDs are sorted.
+// For symbols with a tick size registered with RegisterSymbol, the distance behind the best price
+// and the spread are compared in whole ticks, so differences below a tick are ignored.
+// With WithMoveThresholdBps, Reprice orders are the ones not far from market whose price
+// deviates from the mid price by more than the threshold, wherever they rest.
+type QuoteSignals struct {
+	Crossed       []OrderClientID
+	Reprice       []OrderClientID
+	FarFromMarket []OrderClientID
+}
+
+// signals categorizes the placed limit orders of the symbol against its quote,
+// a zero thresholdBps keeps the spread based repricing. Distances are measured
+// in ticks of tickSize, or in raw price units if it is zero.
+func (m *marketData) signals(thresholdBps uint64, tickSize uint64) QuoteSignals {
+	var signals QuoteSignals
+	tickSize = max(tickSize, 1)
+	var spread uint64
+	if m.ask > m.bid {
+		spread = m.ask.Distance(m.bid) / tickSize
+	}
+	midPrice := m.bid.Mid(m.ask)
+	for clid, orderContext := range m.orders {
+		order := &orderContext.Order
+		if orderContext.Status != OrderPlaced || order.Type == TypeMarket {
+			continue
+		}
+		price := order.Price
+		var behind uint64
+		switch {
+		case m.crosses(order):
+			signals.Crossed = append(signals.Crossed, clid)
+			continue
+		case order.Side == SideBuy && price < m.bid:
+			behind = m.bid.Distance(price)
+		case order.Side == SideSell && price > m.ask:
+			behind = price.Distance(m.ask)
+		case thresholdBps == 0:
+			continue
+		}
+		switch {
+		case behind/tickSize > spread:
+			signals.FarFromMarket = append(signals.FarFromMarket, clid)
+		case thresholdBps == 0 || deviatesBps(price, midPrice, thresholdBps):
+			signals.Reprice = append(signals.Reprice, clid)
+		}
+	}
+	slices.Sort(signals.Crossed)
+	slices.Sort(signals.Reprice)
+	slices.Sort(signals.FarFromMarket)
+	return signals
+}
+
+// crosses reports whether the order is through the spread or on the wrong side of the mid price.
+// Prices are compared to the exact mid price, doubled to stay in integers.
+func (m *marketData) crosses(order *Order) bool {
+	price := uint64(order.Price)
+	doubledMid := mul64(uint64(m.bid), 1).add(mul64(uint64(m.ask), 1))
+	switch order.Side {
+	case SideBuy:
+		return order.Price >= m.ask || mul64(price, 2).cmp(doubledMid) > 0
+	case SideSell:
+		return order.Price <= m.bid || mul64(price, 2).cmp(doubledMid) < 0
+	default:
+		return false
+	}
+}
+
+// deviatesBps reports whether the price deviates from the mid price by more than thresholdBps
+// basis points of the mid price, the products are compared in 128 bits so they never overflow.
+func deviatesBps(price, midPrice Price, thresholdBps uint64) bool {
+	return mul64(price.Distance(midPrice), 10000).cmp(mul64(uint64(midPrice), thresholdBps)) > 0
+}
@@ -0,0 +1,276 @@
+package orderstracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTracker_PushQuoteSignals(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	newOrder := func(clid OrderClientID, side OrderSide, price Price) Order {
+		order := NewOrder(clid, ExchangeBinance, "TEST", 1, price)
+		order.Side = side
+		return order
+	}
+	placed := []Order{
+		newOrder("BUY_CROSSED", SideBuy, 102),
+		newOrder("SELL_CROSSED", SideSell, 100),
+		newOrder("BUY_INSIDE", SideBuy, 101),
+		newOrder("BUY_AT_BID", SideBuy, 100),
+		newOrder("BUY_REPRICE", SideBuy, 99),
+		newOrder("SELL_REPRICE", SideSell, 104),
+		newOrder("BUY_FAR", SideBuy, 97),
+		newOrder("SELL_FAR", SideSell, 110),
+	}
+	for _, order := range placed {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderPlacing(newOrder("BUY_PLACING", SideBuy, 102)); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving("BUY_FAR"); e != nil {
+		t.Fatal(e)
+	}
+
+	signals, e := tracker.PushQuote(ExchangeBinance, "TEST", 100, 102)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"BUY_CROSSED", "SELL_CROSSED"}; !slices.Equal(signals.Crossed, want) {
+		t.Errorf("Unexpected crossed orders: %v != %v", signals.Crossed, want)
+	}
+	if want := []OrderClientID{"BUY_REPRICE", "SELL_REPRICE"}; !slices.Equal(signals.Reprice, want) {
+		t.Errorf("Unexpected orders to reprice: %v != %v", signals.Reprice, want)
+	}
+	if want := []OrderClientID{"SELL_FAR"}; !slices.Equal(signals.FarFromMarket, want) {
+		t.Errorf("Unexpected orders far from market: %v != %v", signals.FarFromMarket, want)
+	}
+
+	signals, e = tracker.PushQuote(ExchangeKraken, "TEST", 100, 102)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(signals.Crossed)+len(signals.Reprice)+len(signals.FarFromMarket) != 0 {
+		t.Errorf("Should not signal orders of another exchange: %+v", signals)
+	}
+}
+
+func TestTracker_PushQuoteSignalsWrongSideOfMid(t *testing.T) {
+	tracker := NewTracker(WithMoveThresholdBps(10000))
+	now := time.Now()
+	for _, order := range []Order{
+		NewOrder("BUY", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("SELL", ExchangeBinance, "TEST", 1, 102),
+	} {
+		order.Side = SideBuy
+		if order.ClientID == "SELL" {
+			order.Side = SideSell
+		}
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	// Mid is 100.5, the bid is below and the ask is above it
+	signals, e := tracker.PushQuote(ExchangeBinance, "TEST", 99, 102)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(signals.Crossed) != 0 {
+		t.Errorf("Should not flag orders on the right side of mid: %v", signals.Crossed)
+	}
+	// Mid moves down to 99.5, the bid is above it though not marketable
+	signals, e = tracker.PushQuote(ExchangeBinance, "TEST", 98, 101)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"BUY"}; !slices.Equal(signals.Crossed, want) {
+		t.Errorf("Unexpected crossed orders: %v != %v", signals.Crossed, want)
+	}
+	// Mid moves up to 102.5, the ask is below it
+	signals, e = tracker.PushQuote(ExchangeBinance, "TEST", 101, 104)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"SELL"}; !slices.Equal(signals.Crossed, want) {
+		t.Errorf("Unexpected crossed orders: %v != %v", signals.Crossed, want)
+	}
+}
+
+func TestTracker_PushQuoteSignalsInTicks(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	tracker.RegisterSymbol(ExchangeBinance, "TICK", SymbolSpec{TickSize: 5})
+	for _, symbol := range []SymbolID{"TICK", "RAW"} {
+		order := NewOrder(OrderClientID(symbol), ExchangeBinance, symbol, 1, 89)
+		order.Side = SideBuy
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	// The order is 11 behind the bid, the spread is 10, both are 2 whole ticks
+	signals, e := tracker.PushQuote(ExchangeBinance, "TICK", 100, 110)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"TICK"}; !slices.Equal(signals.Reprice, want) || len(signals.FarFromMarket) != 0 {
+		t.Errorf("Should compare distances in ticks: %+v", signals)
+	}
+	signals, e = tracker.PushQuote(ExchangeBinance, "RAW", 100, 110)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"RAW"}; !slices.Equal(signals.FarFromMarket, want) || len(signals.Reprice) != 0 {
+		t.Errorf("Should compare raw distances without tick size: %+v", signals)
+	}
+}
+
+func TestTracker_WithMoveThresholdBps(t *testing.T) {
+	tracker := NewTracker(WithMoveThresholdBps(50))
+	if tracker.MoveThresholdBps() != 50 {
+		t.Errorf("Unexpected move threshold: %v", tracker.MoveThresholdBps())
+	}
+	if NewTracker().MoveThresholdBps() != 0 {
+		t.Error("Should have no move threshold by default")
+	}
+	if NewTracker(WithMoveThreshold(50)).MoveThresholdBps() != 50 {
+		t.Error("Should keep WithMoveThreshold as an alias")
+	}
+	now := time.Now()
+	newOrder := func(clid OrderClientID, side OrderSide, price Price) Order {
+		order := NewOrder(clid, ExchangeBinance, "TEST", 1, price)
+		order.Side = side
+		return order
+	}
+	// Mid is 10000, so the threshold is 50 price units
+	placed := []Order{
+		newOrder("BUY_AT_THRESHOLD", SideBuy, 9950),
+		newOrder("BUY_BEYOND", SideBuy, 9949),
+		newOrder("SELL_AT_THRESHOLD", SideSell, 10050),
+		newOrder("SELL_BEYOND", SideSell, 10051),
+		newOrder("BUY_FAR", SideBuy, 9600),
+	}
+	for _, order := range placed {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	signals, e := tracker.PushQuote(ExchangeBinance, "TEST", 9900, 10100)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"BUY_BEYOND", "SELL_BEYOND"}; !slices.Equal(signals.Reprice, want) {
+		t.Errorf("Unexpected orders to reprice: %v != %v", signals.Reprice, want)
+	}
+	if want := []OrderClientID{"BUY_FAR"}; !slices.Equal(signals.FarFromMarket, want) {
+		t.Errorf("Unexpected orders far from market: %v != %v", signals.FarFromMarket, want)
+	}
+
+	// Mid moves up to 10001, so buys at 9950 deviate by 51 units, 50.99 bps, and sells at 10051 by 49.99 bps
+	signals, e = tracker.PushQuote(ExchangeBinance, "TEST", 9902, 10100)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderClientID{"BUY_AT_THRESHOLD", "BUY_BEYOND"}; !slices.Equal(signals.Reprice, want) {
+		t.Errorf("Unexpected orders to reprice: %v != %v", signals.Reprice, want)
+	}
+}
+
+func Test_deviatesBps(t *testing.T) {
+	if deviatesBps(math.MaxUint64-1, math.MaxUint64/2, 10000) {
+		t.Error("Should not deviate by more than 100% at exactly 100%")
+	}
+	if !deviatesBps(math.MaxUint64-1, math.MaxUint64/2, 9999) {
+		t.Error("Should deviate by more than 99.99% near the uint64 limit")
+	}
+	if deviatesBps(math.MaxUint64, math.MaxUint64, 0) {
+		t.Error("Should not deviate from itself")
+	}
+}
+
+func TestTracker_PushQuotes(t *testing.T) {
+	var logged []error
+	tracker := NewTracker(WithValidation(), WithErrorLogger(func(op string, clid OrderClientID, err error) {
+		logged = append(logged, err)
+	}))
+	order := NewOrder("BUY_CROSSED", ExchangeBinance, "BTCUSDT", 1, 102)
+	order.Side = SideBuy
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, time.Now()); e != nil {
+		t.Fatal(e)
+	}
+
+	signals := tracker.PushQuotes(ExchangeBinance, []SymbolQuote{
+		{Symbol: "ETHUSDT", Bid: 10, Ask: 11},
+		{Symbol: "", Bid: 1, Ask: 2},
+		{Symbol: "BTCUSDT", Bid: 100, Ask: 102},
+	})
+	if len(signals) != 3 {
+		t.Fatalf("Should return signals of every quote: %+v", signals)
+	}
+	if !slices.Equal(signals[2].Crossed, []OrderClientID{"BUY_CROSSED"}) || len(signals[0].Crossed) != 0 {
+		t.Errorf("Unexpected signals: %+v", signals)
+	}
+	if len(logged) != 1 || !errors.Is(logged[0], ErrInvalidQuote) {
+		t.Errorf("Should log invalid quote: %v", logged)
+	}
+	for _, symbol := range []SymbolID{"ETHUSDT", "BTCUSDT"} {
+		if _, ok := tracker.GetMarketQuote(ExchangeBinance, symbol); !ok {
+			t.Errorf("Should push quote of %v", symbol)
+		}
+	}
+}
+
+// newQuoteBatch returns quotes of n symbols on a tracker with an order on every symbol.
+func newQuoteBatch(b *testing.B, tracker *Tracker, n int) []SymbolQuote {
+	quotes := make([]SymbolQuote, n)
+	for i := range quotes {
+		symbol := SymbolID(fmt.Sprintf("SYMBOL%d", i))
+		if e := tracker.OrderPlacing(NewOrder(OrderClientID(symbol), ExchangeBinance, symbol, 1, 100)); e != nil {
+			b.Fatal(e)
+		}
+		quotes[i] = SymbolQuote{Symbol: symbol, Bid: 99, Ask: 101}
+	}
+	return quotes
+}
+
+func BenchmarkTracker_PushQuote(b *testing.B) {
+	tracker := NewTracker()
+	quotes := newQuoteBatch(b, tracker, 500)
+	for b.Loop() {
+		for _, quote := range quotes {
+			_, _ = tracker.PushQuote(ExchangeBinance, quote.Symbol, quote.Bid, quote.Ask)
+		}
+	}
+}
+
+func BenchmarkTracker_PushQuotes(b *testing.B) {
+	tracker := NewTracker()
+	quotes := newQuoteBatch(b, tracker, 500)
+	for b.Loop() {
+		tracker.PushQuotes(ExchangeBinance, quotes)
+	}
+}
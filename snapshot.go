@@ -0,0 +1,75 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// OrderSnapshot is a point-in-time copy of a tracked order state.
+type OrderSnapshot struct {
+	Status      OrderStatus
+	Order       Order
+	LastReport  ExecutionReport
+	StatusSince time.Time
+	PlacingTime time.Time
+	PlacedTime  time.Time
+	Fills       []Fill
+	History     []OrderTransition
+	Fees        int64
+}
+
+// snapshot returns a copy of the order state sharing no memory with the order context.
+func (c *orderContext) snapshot() OrderSnapshot {
+	return OrderSnapshot{
+		Status:      c.Status,
+		Order:       c.Order,
+		LastReport:  c.LastReport,
+		StatusSince: c.StatusSince,
+		PlacingTime: c.PlacingTime,
+		PlacedTime:  c.PlacedTime,
+		Fills:       slices.Clone(c.Fills),
+		History:     slices.Clone(c.History),
+		Fees:        c.Fees,
+	}
+}
+
+// GetSnapshot returns copies of all tracked orders sorted by client ID,
+// so snapshots of trackers in the same state are identical.
+func (t *Tracker) GetSnapshot() []OrderSnapshot {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	snapshots := make([]OrderSnapshot, 0, len(t.orders))
+	for _, orderContext := range t.orders {
+		snapshots = append(snapshots, orderContext.snapshot())
+	}
+	sortSnapshots(snapshots)
+	return snapshots
+}
+
+// GetSnapshotForExchange returns copies of the tracked orders of the exchange sorted by client ID,
+// with the same copy semantics as GetSnapshot.
+func (t *Tracker) GetSnapshotForExchange(exchange ExchangeID) []OrderSnapshot {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var snapshots []OrderSnapshot
+	for _, symbolContext := range t.exchanges[exchange] {
+		for _, orderContext := range symbolContext.orders {
+			snapshots = append(snapshots, orderContext.snapshot())
+		}
+	}
+	sortSnapshots(snapshots)
+	return snapshots
+}
+
+// sortSnapshots sorts the snapshots by client ID.
+func sortSnapshots(snapshots []OrderSnapshot) {
+	slices.SortFunc(snapshots, func(a, b OrderSnapshot) int {
+		return strings.Compare(string(a.Order.ClientID), string(b.Order.ClientID))
+	})
+}
@@ -0,0 +1,39 @@
+package orderstracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_GetSnapshotForExchange(t *testing.T) {
+	tracker := NewTracker(WithFillHistory())
+	now := time.Now()
+	orders := []Order{
+		NewOrder("B", ExchangeBinance, "BTCUSDT", 1, 100),
+		NewOrder("A", ExchangeBinance, "ETHUSDT", 2, 200),
+		NewOrder("C", ExchangeKraken, "BTCUSDT", 3, 300),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderFilled("A", now, 1, 200); e != nil {
+		t.Fatal(e)
+	}
+
+	got := tracker.GetSnapshotForExchange(ExchangeBinance)
+	if len(got) != 2 || got[0].Order.ClientID != "A" || got[1].Order.ClientID != "B" {
+		t.Fatalf("Should return sorted orders of the exchange only: %+v", got)
+	}
+	if len(got[0].Fills) != 1 {
+		t.Errorf("Should copy fills: %+v", got[0].Fills)
+	}
+	got[0].Fills[0].Amount = 42
+	if again := tracker.GetSnapshotForExchange(ExchangeBinance); again[0].Fills[0].Amount != 1 {
+		t.Error("Should not share memory with tracked orders")
+	}
+	if got := tracker.GetSnapshotForExchange(ExchangeNone); len(got) != 0 {
+		t.Errorf("Should return no orders for exchange without orders: %+v", got)
+	}
+}
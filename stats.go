@@ -0,0 +1,44 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "time"
+
+// TrackerStats holds cumulative counters of successful order transitions.
+// Counters are monotonic over the tracker lifetime and are not affected by Reset.
+type TrackerStats struct {
+	Placing  uint64 // orders registered with OrderPlacing
+	Placed   uint64 // placements confirmed
+	Moved    uint64 // modifications confirmed
+	Rejected uint64 // placements, modifications and cancellations rejected
+	Canceled uint64 // cancellations confirmed
+	Expired  uint64 // orders expired by ExpireOrders or OrderExpired
+	Filled   uint64 // fills applied
+}
+
+// Stats returns a copy of the cumulative transition counters.
+func (t *Tracker) Stats() TrackerStats {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	return t.stats
+}
+
+// PhaseDurations returns the total time orders spent in each status they left,
+// accumulated at transitions from the time the order entered the status.
+// Only statuses left at least once are present. Like the counters, the durations
+// are cumulative over the tracker lifetime and are not affected by Reset.
+// Transitions timestamped before the time the order entered the status add nothing,
+// which happens when local and exchange clocks disagree.
+func (t *Tracker) PhaseDurations() map[OrderStatus]time.Duration {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	durations := make(map[OrderStatus]time.Duration)
+	for status, duration := range t.phases {
+		if duration > 0 {
+			durations[OrderStatus(status)] = duration
+		}
+	}
+	return durations
+}
@@ -0,0 +1,130 @@
+package orderstracker
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTracker_Stats(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	orders := []Order{
+		GenerateOrderWithSymbol("TEST"),
+		GenerateOrderWithSymbol("TEST"),
+		GenerateOrderWithSymbol("TEST"),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderPlacing(orders[0]); e == nil {
+		t.Fatal("Should not place duplicate order")
+	}
+	if _, e := tracker.OrderRejected(orders[0].ClientID, now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	for _, order := range orders[1:] {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderMoving(orders[1].ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(orders[1].ClientID, now, 1); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(orders[1].ClientID, now, 1, 1); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(orders[2].ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(orders[2].ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	tracker.Reset()
+
+	want := TrackerStats{Placing: 3, Placed: 2, Moved: 1, Rejected: 1, Canceled: 1, Filled: 1}
+	if got := tracker.Stats(); got != want {
+		t.Errorf("Unexpected stats: %+v", got)
+	}
+}
+
+// expvarRuns makes expvar names unique across repeated runs of the tests in the same process.
+var expvarRuns atomic.Int64
+
+func TestTracker_WithExpvar(t *testing.T) {
+	name := fmt.Sprintf("orderstracker_test_%d", expvarRuns.Add(1))
+	tracker := NewTracker(WithExpvar(name))
+	if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+		t.Fatal(e)
+	}
+	published := expvar.Get(name)
+	if published == nil {
+		t.Fatal("Should publish stats")
+	}
+	var got TrackerStats
+	if e := json.Unmarshal([]byte(published.String()), &got); e != nil {
+		t.Fatal(e)
+	}
+	if got.Placing != 1 {
+		t.Errorf("Unexpected published stats: %+v", got)
+	}
+}
+
+func TestTracker_PhaseDurations(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	now := start
+	tracker.now = func() time.Time { return now }
+	first := NewOrder("FIRST", ExchangeBinance, "TEST", 1, 100)
+	second := NewOrder("SECOND", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{first, second} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed(first.ClientID, start.Add(100*time.Millisecond)); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(second.ClientID, start.Add(300*time.Millisecond)); e != nil {
+		t.Fatal(e)
+	}
+	now = start.Add(time.Second)
+	if e := tracker.OrderMoving(first.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(first.ClientID, start.Add(1500*time.Millisecond), 101); e != nil {
+		t.Fatal(e)
+	}
+	now = start.Add(2 * time.Second)
+	if e := tracker.OrderCancelling(second.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(second.ClientID, start.Add(2200*time.Millisecond)); e != nil {
+		t.Fatal(e)
+	}
+
+	want := map[OrderStatus]time.Duration{
+		OrderPlacing:   400 * time.Millisecond,
+		OrderPlaced:    2600 * time.Millisecond,
+		OrderModifying: 500 * time.Millisecond,
+		OrderCanceling: 200 * time.Millisecond,
+	}
+	tracker.Reset()
+	got := tracker.PhaseDurations()
+	if len(got) != len(want) {
+		t.Errorf("Unexpected phases: %v", got)
+	}
+	for status, duration := range want {
+		if got[status] != duration {
+			t.Errorf("Unexpected duration of %s: %v != %v", status, got[status], duration)
+		}
+	}
+}
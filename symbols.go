@@ -0,0 +1,53 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import "fmt"
+
+// SymbolSpec describes how raw prices and amounts of a symbol are scaled.
+// PriceScale and AmountScale are the number of decimal digits of the raw values,
+// so a raw price p means p / 10^PriceScale. TickSize is the minimal raw price increment.
+type SymbolSpec struct {
+	PriceScale  uint8
+	AmountScale uint8
+	TickSize    uint64
+}
+
+// RegisterSymbol sets the spec of the symbol on the exchange, replacing the previous one.
+// Specs are configuration, so they survive Reset. Returns ErrTrackerClosed after Close.
+func (t *Tracker) RegisterSymbol(exchange ExchangeID, symbol SymbolID, spec SymbolSpec) error {
+	t.guard.Lock()
+	defer t.unlock()
+
+	if err := t.open(); err != nil {
+		return t.failed("RegisterSymbol", "", err)
+	}
+	specs := t.specs[exchange]
+	if specs == nil {
+		specs = make(map[SymbolID]SymbolSpec)
+		t.specs[exchange] = specs
+	}
+	specs[symbol] = spec
+	return nil
+}
+
+// GetSymbolSpec returns the spec of the symbol on the exchange.
+// The boolean result is false if the symbol is not registered.
+func (t *Tracker) GetSymbolSpec(exchange ExchangeID, symbol SymbolID) (SymbolSpec, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	spec, exists := t.specs[exchange][symbol]
+	return spec, exists
+}
+
+// tickSize returns the tick size of the symbol on the exchange, the guard should be held.
+// Returns an error if the symbol is not registered or has no tick size.
+func (t *Tracker) tickSize(exchange ExchangeID, symbol SymbolID) (uint64, error) {
+	spec := t.specs[exchange][symbol]
+	if spec.TickSize == 0 {
+		return 0, fmt.Errorf("symbol has no tick size (exchange %v, symbol %v)", exchange, symbol)
+	}
+	return spec.TickSize, nil
+}
@@ -19,281 +19,1892 @@
 package orderstracker
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
 
 // orderContext holds the context and execution state of an order.
 // It contains the current order status, the original order details,
-// and the most recent execution report.
+// the most recent execution report and the fills applied to the order.
+// Fills are kept with WithFillHistory only, the order totals include every fill.
+// StatusSince is the time the order entered its current status,
+// History keeps every applied transition for audit purposes, consecutive partial fills share an entry.
+// Fees accumulates fees of the fills, negative for rebates.
+// MovePending is set when the order is canceled while its modification is not acknowledged.
+// Trimmed aggregates the fills dropped from Fills by TrimHistory or not kept without WithFillHistory.
+// Improvement accumulates the value by which fills were better than the order price.
 type orderContext struct {
-	Status     OrderStatus
-	Order      Order
-	LastReport ExecutionReport
+	Status      OrderStatus
+	Order       Order
+	LastReport  ExecutionReport
+	StatusSince time.Time
+	PlacingTime time.Time
+	PlacedTime  time.Time
+	Fills       []Fill
+	TradeIDs    map[string]struct{}
+	History     []OrderTransition
+	Fees        int64
+	MovePending bool
+	Trimmed     trimmedFills
+	Improvement uint128
+
+	// Exact totals of all fills and of the fills aggregated in LastReport,
+	// so average prices are computed without accumulating rounding errors
+	FilledAmount uint64
+	FilledValue  uint128
+	ReportValue  uint128
+
+	// Order as requested at placement, kept unchanged by modifications
+	OriginalOrder Order
+}
+
+// trimmedFills describes fills dropped from the order fill history,
+// their amount and value stay in the order totals.
+type trimmedFills struct {
+	Count     int
+	FirstTime time.Time
+}
+
+// add counts the fill in the aggregate.
+func (f *trimmedFills) add(fill Fill) {
+	if f.Count == 0 {
+		f.FirstTime = fill.Time
+	}
+	f.Count++
+}
+
+// filled returns the total executed amount and value (amount × price) of the order fills,
+// including the trimmed ones.
+func (c *orderContext) filled() (amount uint64, value uint128) {
+	return c.FilledAmount, c.FilledValue
+}
+
+// remaining returns the order amount not executed yet.
+func (c *orderContext) remaining() uint64 {
+	filledAmount, _ := c.filled()
+	if filledAmount >= c.Order.Amount {
+		return 0
+	}
+	return c.Order.Amount - filledAmount
+}
+
+// improvement returns the value (amount × price difference) by which the fill price
+// is better than the order price: lower for buy orders and higher for sell orders.
+// It is zero for fills at worse prices, market orders and orders without side.
+func (c *orderContext) improvement(fill Fill) uint128 {
+	order := &c.Order
+	switch {
+	case order.Type == TypeMarket:
+		return uint128{}
+	case order.Side == SideBuy && fill.Price < order.Price,
+		order.Side == SideSell && fill.Price > order.Price:
+		return mul64(fill.Amount, fill.Price.Distance(order.Price))
+	default:
+		return uint128{}
+	}
+}
+
+// displayed returns the amount of the displayed slice not executed yet.
+// An iceberg order displays slices of DisplayQuantity one after another: fills execute
+// the displayed slice and once it is exhausted, the next slice is refreshed from the hidden
+// remainder, so the last slice may be smaller. Orders without DisplayQuantity or with
+// DisplayQuantity not less than the amount display the whole remaining amount.
+func (c *orderContext) displayed() uint64 {
+	remaining := c.remaining()
+	display := c.Order.DisplayQuantity
+	if display == 0 || display >= c.Order.Amount {
+		return remaining
+	}
+	filledAmount, _ := c.filled()
+	return min(display-filledAmount%display, remaining)
+}
+
+// firstFillTime returns the time of the first fill, the boolean result is false if there are no fills.
+func (c *orderContext) firstFillTime() (time.Time, bool) {
+	if c.Trimmed.Count > 0 {
+		return c.Trimmed.FirstTime, true
+	}
+	if len(c.Fills) == 0 {
+		return time.Time{}, false
+	}
+	return c.Fills[0].Time, true
+}
+
+// trimFills drops the oldest fills keeping at most limit of them and counts the dropped ones
+// in the trimmed aggregate. Returns the number of dropped fills.
+func (c *orderContext) trimFills(limit int) int {
+	dropped := len(c.Fills) - limit
+	if dropped <= 0 {
+		return 0
+	}
+	for _, fill := range c.Fills[:dropped] {
+		c.Trimmed.add(fill)
+	}
+	// Copying releases the memory of dropped fills
+	c.Fills = slices.Clone(c.Fills[dropped:])
+	return dropped
+}
+
+// clone returns a deep copy of the order context sharing no memory with the original.
+func (c *orderContext) clone() *orderContext {
+	cloned := *c
+	cloned.Fills = slices.Clone(c.Fills)
+	cloned.TradeIDs = maps.Clone(c.TradeIDs)
+	cloned.History = slices.Clone(c.History)
+	return &cloned
+}
+
+// setStatus moves the order into the given status remembering the time it happened.
+// The time is kept unchanged if the order is already in the status.
+func (c *orderContext) setStatus(status OrderStatus, since time.Time) {
+	if c.Status == status {
+		return
+	}
+	c.Status = status
+	c.StatusSince = since
+}
+
+// mid returns the price halfway between the bid and ask rounded down,
+// the boolean result is false if no quote was pushed.
+func (m *marketData) mid() (Price, bool) {
+	if !m.hasQuote {
+		return 0, false
+	}
+	return m.bid.Mid(m.ask), true
+}
+
+// spreadBps returns the spread in basis points of the mid price rounded down, the boolean result
+// is false if no quote was pushed, the quote is crossed or the mid price is zero.
+func (m *marketData) spreadBps() (uint64, bool) {
+	if !m.hasQuote || m.ask < m.bid {
+		return 0, false
+	}
+	midPrice := m.bid.Mid(m.ask)
+	if midPrice == 0 {
+		return 0, false
+	}
+	// Spread is at most twice the mid price plus one, so the quotient fits uint64
+	return mul64(m.ask.Distance(m.bid), 10000).div64(uint64(midPrice)), true
+}
+
+// setStatus moves the order into the status like orderContext.setStatus, adds the time
+// the order spent in the previous status to the phase durations and queues the notification
+// of OnStatusChange handlers, the guard should be held.
+func (t *Tracker) setStatus(c *orderContext, status OrderStatus, since time.Time) {
+	if c.Status == status {
+		return
+	}
+	if since.After(c.StatusSince) {
+		t.phases[c.Status] += since.Sub(c.StatusSince)
+	}
+	t.pendingChanges = append(t.pendingChanges, statusChange{clid: c.Order.ClientID, from: c.Status, to: status})
+	c.setStatus(status, since)
 }
 
 // marketData holds the latest market quote data for a symbol.
-// It includes bid and ask prices and an optional pointer to an order context
-// that may be associated with the market data.
+// It includes bid and ask prices and sizes and the contexts of orders placed on the symbol,
+// which are kept until the orders are purged. hasQuote is set once a quote is pushed.
 type marketData struct {
-	bid          uint64
-	ask          uint64
-	orderContext *orderContext
+	bid      Price
+	ask      Price
+	bidSize  uint64
+	askSize  uint64
+	hasQuote bool
+	orders   map[OrderClientID]*orderContext
+}
+
+// MarketQuote is the latest quote of a symbol on an exchange.
+// Sizes are zero if the quote was pushed without them.
+type MarketQuote struct {
+	Bid     Price
+	Ask     Price
+	BidSize uint64
+	AskSize uint64
+}
+
+// add starts tracking the order on the symbol, the orders map is created with room for capacity orders.
+func (m *marketData) add(c *orderContext, capacity int) {
+	if m.orders == nil {
+		m.orders = make(map[OrderClientID]*orderContext, capacity)
+	}
+	m.orders[c.Order.ClientID] = c
 }
 
 // Tracker is responsible for tracking the state of orders and market data.
 // It maintains a synchronized view of orders across different exchanges and symbols.
 type Tracker struct {
-	guard     sync.Mutex
-	exchanges map[ExchangeID]map[SymbolID]marketData
-	orders    map[OrderClientID]*orderContext
+	guard       sync.Mutex
+	exchanges   map[ExchangeID]map[SymbolID]marketData
+	specs       map[ExchangeID]map[SymbolID]SymbolSpec
+	orders      map[OrderClientID]*orderContext
+	ackLatency  map[ExchangeID]latencyStats
+	offline     map[ExchangeID]struct{}
+	now         func() time.Time
+	halted      bool
+	haltReason  string
+	validation  bool
+	strictFills bool
+	fillHistory bool
+	maxOrders   int
+	maxFills    int
+	moveBps     uint64
+	// Initial capacities of orders and exchanges maps
+	orderCapacity    int
+	exchangeCapacity int
+	rounding         VWAPRounding
+	stats            TrackerStats
+	phases           [OrderUnknown + 1]time.Duration
+	events           *eventLog
+
+	pendingFills        []FillEvent
+	pendingRejects      []rejection
+	pendingChanges      []statusChange
+	pendingExpires      []OrderClientID
+	pendingErrors       []failure
+	errorLogger         func(op string, clid OrderClientID, err error)
+	notifyGuard         sync.Mutex
+	fillSubscriptions   []chan FillEvent
+	subscriptionsClosed bool
+	rejectHandlers      []func(OrderClientID, string, OrderStatus)
+	statusHandlers      []func(OrderClientID, OrderStatus, OrderStatus)
+	coalesced           map[OrderClientID]statusChange
+	expireHandlers      []func(OrderClientID)
+
+	purgeInterval    time.Duration
+	purgeRetain      time.Duration
+	coalesceInterval time.Duration
+	expireInterval   time.Duration
+	stop             chan struct{}
+	background       sync.WaitGroup
+	closeOnce        sync.Once
+	closed           bool
 }
 
-// NewTracker creates and initializes a new Tracker instance.
+// NewTracker creates and initializes a new Tracker instance configured with the given options.
 // It returns a pointer to a Tracker with properly initialized maps for exchanges and orders.
-func NewTracker() *Tracker {
-	return &Tracker{
-		exchanges: make(map[ExchangeID]map[SymbolID]marketData),
-		orders:    make(map[OrderClientID]*orderContext),
+func NewTracker(opts ...Option) *Tracker {
+	t := newTracker(opts...)
+	t.start()
+	return t
+}
+
+// newTracker creates a tracker configured with the options without starting its background goroutines.
+func newTracker(opts ...Option) *Tracker {
+	t := &Tracker{
+		specs:      make(map[ExchangeID]map[SymbolID]SymbolSpec),
+		ackLatency: make(map[ExchangeID]latencyStats),
+		offline:    make(map[ExchangeID]struct{}),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
+	t.exchanges = make(map[ExchangeID]map[SymbolID]marketData, t.exchangeCapacity)
+	t.orders = make(map[OrderClientID]*orderContext, t.orderCapacity)
+	if t.coalesceInterval > 0 {
+		t.coalesced = make(map[OrderClientID]statusChange)
+	}
+	return t
 }
 
-// OrderPlacing registers a new order in the tracker as pending placement.
-// If the order already exists, it returns an error.
-func (t *Tracker) OrderPlacing(order Order) error {
+// start starts the background goroutines requested by the options.
+func (t *Tracker) start() {
+	if t.purgeInterval > 0 || t.coalesceInterval > 0 || t.expireInterval > 0 {
+		t.stop = make(chan struct{})
+	}
+	if t.purgeInterval > 0 {
+		t.background.Add(1)
+		go t.autoPurge(t.purgeInterval, t.purgeRetain)
+	}
+	if t.coalesceInterval > 0 {
+		t.background.Add(1)
+		go t.deliverCoalesced(t.coalesceInterval)
+	}
+	if t.expireInterval > 0 {
+		t.background.Add(1)
+		go t.autoExpire(t.expireInterval)
+	}
+}
+
+// Reset wipes all orders and market data, keeping the tracker usable for a new session.
+// Configuration, symbol specs, the halt state, disconnected exchanges, acknowledgment latency statistics and cumulative counters
+// survive the reset. Returns ErrTrackerClosed after Close.
+func (t *Tracker) Reset() error {
+	t.guard.Lock()
+	defer t.unlock()
+	if err := t.open(); err != nil {
+		return t.failed("Reset", "", err)
+	}
+	t.reset()
+	return nil
+}
+
+// reset implements Reset, the guard should be held.
+func (t *Tracker) reset() {
+	t.exchanges = make(map[ExchangeID]map[SymbolID]marketData, t.exchangeCapacity)
+	t.orders = make(map[OrderClientID]*orderContext, t.orderCapacity)
+	t.emit(Event{Kind: EventReset, Time: t.now()})
+}
+
+// Clone returns a deep copy of the tracker including configuration, orders and market data.
+// The copy shares no mutable state with the original, so they can be mutated independently.
+// The event log, fill subscriptions and handlers are not cloned, the copy records no events
+// and has no subscribers. The copy doesn't purge orders automatically.
+func (t *Tracker) Clone() *Tracker {
 	t.guard.Lock()
 	defer t.guard.Unlock()
 
-	if _, exists := t.orders[order.ClientID]; exists {
-		return fmt.Errorf("order already placed (clid %v)", order.ClientID)
+	cloned := &Tracker{
+		exchanges:        make(map[ExchangeID]map[SymbolID]marketData, len(t.exchanges)),
+		specs:            make(map[ExchangeID]map[SymbolID]SymbolSpec, len(t.specs)),
+		orders:           make(map[OrderClientID]*orderContext, len(t.orders)),
+		ackLatency:       maps.Clone(t.ackLatency),
+		offline:          maps.Clone(t.offline),
+		now:              t.now,
+		halted:           t.halted,
+		haltReason:       t.haltReason,
+		validation:       t.validation,
+		strictFills:      t.strictFills,
+		fillHistory:      t.fillHistory,
+		maxOrders:        t.maxOrders,
+		maxFills:         t.maxFills,
+		moveBps:          t.moveBps,
+		orderCapacity:    t.orderCapacity,
+		exchangeCapacity: t.exchangeCapacity,
+		rounding:         t.rounding,
+		errorLogger:      t.errorLogger,
+		stats:            t.stats,
+		phases:           t.phases,
 	}
+	for clid, orderContext := range t.orders {
+		cloned.orders[clid] = orderContext.clone()
+	}
+	for exchangeID, exchange := range t.exchanges {
+		clonedExchange := make(map[SymbolID]marketData, len(exchange))
+		for symbolID, symbolContext := range exchange {
+			if symbolContext.orders != nil {
+				clonedOrders := make(map[OrderClientID]*orderContext, len(symbolContext.orders))
+				for clid := range symbolContext.orders {
+					clonedOrders[clid] = cloned.orders[clid]
+				}
+				symbolContext.orders = clonedOrders
+			}
+			clonedExchange[symbolID] = symbolContext
+		}
+		cloned.exchanges[exchangeID] = clonedExchange
+	}
+	for exchangeID, specs := range t.specs {
+		cloned.specs[exchangeID] = maps.Clone(specs)
+	}
+	return cloned
+}
 
-	orderContext := &orderContext{
-		Status: OrderPlacing,
-		Order:  order,
+// Halt puts the tracker into the halted state with the given reason.
+// While halted, every mutating order method returns ErrHalted, reads keep working.
+// Returns ErrTrackerClosed after Close.
+func (t *Tracker) Halt(reason string) error {
+	t.guard.Lock()
+	defer t.unlock()
+	if err := t.open(); err != nil {
+		return t.failed("Halt", "", err)
 	}
-	t.orders[order.ClientID] = orderContext
+	t.halt(reason)
+	return nil
+}
 
-	exchange := t.exchanges[order.Exchange]
-	if exchange == nil {
-		exchange = make(map[SymbolID]marketData)
-		t.exchanges[order.Exchange] = exchange
+// halt implements Halt, the guard should be held.
+func (t *Tracker) halt(reason string) {
+	t.halted = true
+	t.haltReason = reason
+	t.emit(Event{Kind: EventHalt, Time: t.now(), Reason: reason})
+}
+
+// Unhalt restores normal operation after Halt. Returns ErrTrackerClosed after Close.
+func (t *Tracker) Unhalt() error {
+	t.guard.Lock()
+	defer t.unlock()
+	if err := t.open(); err != nil {
+		return t.failed("Unhalt", "", err)
 	}
-	symbolContext := exchange[order.Symbol]
-	symbolContext.orderContext = orderContext
-	exchange[order.Symbol] = symbolContext
+	t.unhalt()
 	return nil
 }
 
-// OrderPlaceConfirmed confirms that an order has been successfully placed.
-// It takes the order's client ID and the confirmation time as parameters.
-// Returns an error if the order is not found or if the current status is not OrderPlacing.
-func (t *Tracker) OrderPlaceConfirmed(clid OrderClientID, time time.Time) error {
+// unhalt implements Unhalt, the guard should be held.
+func (t *Tracker) unhalt() {
+	t.halted = false
+	t.haltReason = ""
+	t.emit(Event{Kind: EventUnhalt, Time: t.now()})
+}
+
+// IsHalted reports whether the tracker is halted along with the halt reason.
+func (t *Tracker) IsHalted() (bool, string) {
 	t.guard.Lock()
 	defer t.guard.Unlock()
+	return t.halted, t.haltReason
+}
 
-	orderContext := t.orders[clid]
-	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
-	}
-	orderContext.LastReport.Kind = ReportPlaced
-	orderContext.LastReport.Time = time
+// MoveThresholdBps returns the threshold set with WithMoveThresholdBps, zero if it is not set.
+func (t *Tracker) MoveThresholdBps() uint64 {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	return t.moveBps
+}
 
-	if orderContext.Status != OrderPlacing {
-		return fmt.Errorf("order status is not 'OrderPlacing' (clid %v, status '%s')",
-			clid, orderContext.Status)
+// writable returns an error if mutating methods are not allowed at the moment.
+// It should be called with the guard held.
+func (t *Tracker) writable() error {
+	if t.closed {
+		return ErrTrackerClosed
+	}
+	if t.halted {
+		return fmt.Errorf("%w (reason '%s')", ErrHalted, t.haltReason)
 	}
+	return nil
+}
 
-	orderContext.Status = OrderPlaced
+// open returns ErrTrackerClosed after Close, the guard should be held.
+// Unlike writable, it allows calls while the tracker is halted.
+func (t *Tracker) open() error {
+	if t.closed {
+		return ErrTrackerClosed
+	}
 	return nil
 }
 
-// OrderRejected updates an order's state to indicate that it has been rejected.
-// It accepts the order's client ID, the time of rejection, and a reason message.
-// Returns an error if the order is not found or if the status does not allow for rejection.
-func (t *Tracker) OrderRejected(clid OrderClientID, time time.Time, reason string) error {
+// status returns the current status of the order or OrderUnplaced if the order is not found,
+// the guard should be held.
+func (t *Tracker) status(clid OrderClientID) OrderStatus {
+	if orderContext := t.orders[clid]; orderContext != nil {
+		return orderContext.Status
+	}
+	return OrderUnplaced
+}
+
+// MarkExchangeDisconnected marks the exchange as disconnected and returns the sorted client IDs
+// of active orders on it. The state of these orders is unreliable until the connection is restored,
+// so the caller decides whether to treat them as canceled, mark them with MarkOrderUnknown
+// or reconcile them after reconnect.
+// Placing orders on a disconnected exchange fails with ErrExchangeDisconnected.
+// Returns ErrTrackerClosed after Close.
+func (t *Tracker) MarkExchangeDisconnected(exchange ExchangeID) ([]OrderClientID, error) {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
 
-	orderContext := t.orders[clid]
-	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
-	}
-	orderContext.LastReport.Kind = ReportRejected
-	orderContext.LastReport.Time = time
-	orderContext.LastReport.Message = reason
-	if orderContext.Status == OrderPlacing {
-		orderContext.Status = OrderUnplaced
-		return nil
+	if err := t.open(); err != nil {
+		return nil, t.failed("MarkExchangeDisconnected", "", err)
 	}
-	if orderContext.Status == OrderModifying || orderContext.Status == OrderCanceling {
-		orderContext.Status = OrderPlaced
-		return nil
+	t.offline[exchange] = struct{}{}
+	t.emit(Event{Kind: EventDisconnected, Time: t.now(), Exchange: exchange})
+
+	var active []OrderClientID
+	for clid, orderContext := range t.orders {
+		if orderContext.Order.Exchange == exchange && orderContext.Status.isActive() {
+			active = append(active, clid)
+		}
 	}
+	slices.Sort(active)
+	return active, nil
+}
+
+// MarkExchangeReconnected clears the disconnected mark set by MarkExchangeDisconnected.
+// Returns ErrTrackerClosed after Close.
+func (t *Tracker) MarkExchangeReconnected(exchange ExchangeID) error {
+	t.guard.Lock()
+	defer t.unlock()
 
-	return fmt.Errorf("order status should be 'OrderPlacing', 'OrderModifying' or 'OrderCanceling' to reject (clid %v, status '%s')",
-		clid, orderContext.Status)
+	if err := t.open(); err != nil {
+		return t.failed("MarkExchangeReconnected", "", err)
+	}
+	delete(t.offline, exchange)
+	t.emit(Event{Kind: EventReconnected, Time: t.now(), Exchange: exchange})
+	return nil
 }
 
-// OrderMoving initiates the order price modification.
-// It accepts the order's client ID.
-// Returns an error if the order is not found or if the order status is not OrderPlaced.
-func (t *Tracker) OrderMoving(clid OrderClientID) error {
+// MarkOrderUnknown moves an active order into OrderUnknown when its state on the exchange
+// is uncertain, e.g. after a disconnect or a lost acknowledgment found with FindStaleOrders.
+// The execution report is kept unchanged. Use ResolveUnknown once the exchange reports the truth.
+// Returns the order status before the call and an error if the order is not found or is not active.
+func (t *Tracker) MarkOrderUnknown(clid OrderClientID, time time.Time) (OrderStatus, error) {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("MarkOrderUnknown", clid, t.markOrderUnknown(clid, time))
+}
+
+// markOrderUnknown implements MarkOrderUnknown, the guard should be held.
+func (t *Tracker) markOrderUnknown(clid OrderClientID, time time.Time) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
 
 	orderContext := t.orders[clid]
 	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
 	}
-	if orderContext.Status != OrderPlaced {
-		return fmt.Errorf("orderContext status is not 'OrderPlaced' (clid %v, status '%s')",
-			clid, orderContext.Status)
+	from := orderContext.Status
+	if err := checkTransition(orderContext, opMarkUnknown, OrderUnknown); err != nil {
+		return err
 	}
-	orderContext.Status = OrderModifying
-	orderContext.LastReport.Kind = ReportNone
+	t.setStatus(orderContext, OrderUnknown, time)
+	orderContext.record(from, time)
+	t.emit(Event{Kind: EventUnknown, ClientID: clid, Time: time})
 	return nil
 }
 
-// OrderMoveConfirmed confirms a previously initiated order modification.
-// It takes the order's client ID, the confirmation time, and the new price.
-// Returns an error if the order is not found or if the order is not in the OrderModifying state.
-func (t *Tracker) OrderMoveConfirmed(clid OrderClientID, time time.Time, price uint64) error {
+// ResolveUnknown moves an order from OrderUnknown into the status verified with the exchange:
+// OrderPlaced, OrderUnplaced, OrderFilled or OrderExpired. The execution report is kept unchanged.
+// Returns an error if the order is not found, is not in OrderUnknown state or the status is not verified.
+func (t *Tracker) ResolveUnknown(clid OrderClientID, time time.Time, status OrderStatus) error {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
+	return t.failed("ResolveUnknown", clid, t.resolveUnknown(clid, time, status))
+}
+
+// resolveUnknown implements ResolveUnknown, the guard should be held.
+func (t *Tracker) resolveUnknown(clid OrderClientID, time time.Time, status OrderStatus) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
 
 	orderContext := t.orders[clid]
 	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
 	}
+	if err := checkTransition(orderContext, opResolve, status); err != nil {
+		return err
+	}
+	t.setStatus(orderContext, status, time)
+	orderContext.record(OrderUnknown, time)
+	t.emit(Event{Kind: EventResolved, ClientID: clid, Time: time, Status: status})
+	return nil
+}
 
-	orderContext.LastReport.Kind = ReportModified
-	orderContext.LastReport.Time = time
-	orderContext.LastReport.Price = price
+// IsExchangeConnected reports whether the exchange is not marked as disconnected.
+func (t *Tracker) IsExchangeConnected(exchange ExchangeID) bool {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	_, disconnected := t.offline[exchange]
+	return !disconnected
+}
+
+// OrderPlacing registers a new order in the tracker as pending placement.
+// The placing time is taken from the tracker clock and used to measure acknowledgment latency.
+// A client ID of an order in OrderUnplaced or OrderExpired state can be reused
+// to re-place the order: the order starts over with the new parameters, a fresh report and
+// no fills, only its transition history is kept.
+// If an active or filled order with the client ID exists or validation is enabled and the order
+// is malformed, it returns an error. With WithMaxOrders, it returns ErrTooManyOrders
+// if the number of active orders reached the limit. It returns ErrExchangeDisconnected
+// if the order exchange is marked as disconnected.
+func (t *Tracker) OrderPlacing(order Order) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("OrderPlacing", order.ClientID, t.orderPlacing(order))
+}
 
-	if orderContext.Status != OrderModifying {
-		return fmt.Errorf("order status is not 'OrderModifying' (clid %v, status '%s')",
-			clid, orderContext.Status)
+// orderPlacing implements OrderPlacing, the guard should be held.
+func (t *Tracker) orderPlacing(order Order) error {
+	existing, err := t.checkPlacing(order)
+	if err != nil {
+		return err
 	}
 
-	orderContext.Status = OrderPlaced
-	orderContext.Order.Price = price
+	now := t.now()
+	orderContext := &orderContext{
+		Status:        OrderPlacing,
+		Order:         order,
+		OriginalOrder: order,
+		StatusSince:   now,
+		PlacingTime:   now,
+	}
+	from := OrderUnplaced
+	if existing != nil {
+		from = existing.Status
+		orderContext.History = existing.History
+		delete(t.exchanges[existing.Order.Exchange][existing.Order.Symbol].orders, order.ClientID)
+	}
+	t.pendingChanges = append(t.pendingChanges, statusChange{clid: order.ClientID, from: from, to: OrderPlacing})
+	orderContext.record(from, now)
+	t.orders[order.ClientID] = orderContext
+
+	exchange := t.exchanges[order.Exchange]
+	if exchange == nil {
+		exchange = make(map[SymbolID]marketData)
+		t.exchanges[order.Exchange] = exchange
+	}
+	symbolContext := exchange[order.Symbol]
+	symbolContext.add(orderContext, t.orderCapacity)
+	exchange[order.Symbol] = symbolContext
+	t.stats.Placing++
+	t.emit(Event{Kind: EventPlacing, ClientID: order.ClientID, Time: now, Order: order})
 	return nil
 }
 
-// OrderCancelling initiates the cancellation process for an active order.
-// It takes the order's client ID and validates that the order exists and is in the OrderPlaced state.
-// Returns an error if the order does not exist or is not in an appropriate state for cancellation.
-func (t *Tracker) OrderCancelling(clid OrderClientID) error {
+// OrderPlaced registers a new order directly in the OrderPlaced state with a ReportPlaced report
+// for venues acknowledging placements synchronously. It is OrderPlacing followed by
+// OrderPlaceConfirmed with the given time under a single guard acquisition,
+// so the order is validated like in OrderPlacing and queries never observe it in OrderPlacing.
+// OnStatusChange handlers are still notified of both changes, into OrderPlacing and
+// into OrderPlaced, once the guard is released.
+// The placing time is taken from the tracker clock as in OrderPlacing, but the placement
+// adds no sample to the acknowledgment latency reported by AckJitter.
+// Returns the error of OrderPlacing, in which case the order is not registered.
+func (t *Tracker) OrderPlaced(order Order, time time.Time) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("OrderPlaced", order.ClientID, t.orderPlaced(order, time))
+}
+
+// orderPlaced implements OrderPlaced, the guard should be held.
+func (t *Tracker) orderPlaced(order Order, time time.Time) error {
+	if err := t.orderPlacing(order); err != nil {
+		return err
+	}
+	// Placement confirmation of an order in OrderPlacing can't fail
+	return t.placeConfirmed(order.ClientID, time, EventPlaced)
+}
+
+// CanPlace returns the error OrderPlacing would return for the order without registering it.
+func (t *Tracker) CanPlace(order Order) error {
 	t.guard.Lock()
 	defer t.guard.Unlock()
-	orderContext := t.orders[clid]
-	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
+	_, err := t.checkPlacing(order)
+	return err
+}
+
+// checkPlacing validates placing the order and returns the context of the order
+// with the same client ID if it is re-placed, the guard should be held.
+func (t *Tracker) checkPlacing(order Order) (*orderContext, error) {
+	if err := t.writable(); err != nil {
+		return nil, err
+	}
+
+	if t.validation {
+		if err := order.validate(); err != nil {
+			return nil, err
+		}
 	}
-	if orderContext.Status != OrderPlaced {
-		return fmt.Errorf("order status is not 'OrderPlaced' (clid %v, status '%s')",
-			clid, orderContext.Status)
+	if _, disconnected := t.offline[order.Exchange]; disconnected {
+		return nil, fmt.Errorf("%w (clid %v, exchange %v)", ErrExchangeDisconnected, order.ClientID, order.Exchange)
 	}
-	orderContext.Status = OrderCanceling
-	orderContext.LastReport.Kind = ReportNone
-	return nil
+	existing := t.orders[order.ClientID]
+	if existing != nil {
+		if err := checkTransition(existing, opPlace, OrderPlacing); err != nil {
+			return nil, err
+		}
+	}
+	// Active orders can't exceed the limit while all orders are below it
+	if t.maxOrders > 0 && len(t.orders) >= t.maxOrders && t.activeOrdersCount() >= t.maxOrders {
+		return nil, fmt.Errorf("%w (clid %v, limit %d)", ErrTooManyOrders, order.ClientID, t.maxOrders)
+	}
+	return existing, nil
 }
 
-// OrderCancelConfirmed finalizes an order cancellation.
-// It takes the order's client ID and the confirmation time as parameters.
-// Returns an error if the order is not found or if the order is not in the OrderCanceling state.
-func (t *Tracker) OrderCancelConfirmed(clid OrderClientID, time time.Time) error {
+// PurgeCompleted removes orders in OrderUnplaced, OrderFilled or OrderExpired state
+// that entered the state before the given time, releasing their memory.
+// Purged client IDs can be placed again as new orders.
+// Returns the number of removed orders, or 0 while the tracker is halted.
+func (t *Tracker) PurgeCompleted(before time.Time) int {
 	t.guard.Lock()
 	defer t.guard.Unlock()
 
-	orderContext := t.orders[clid]
-	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
+	if t.writable() != nil {
+		return 0
 	}
+	return t.purgeCompleted(before)
+}
 
-	orderContext.LastReport.Kind = ReportCanceled
-	orderContext.LastReport.Time = time
+// purgeCompleted implements PurgeCompleted, the guard should be held.
+func (t *Tracker) purgeCompleted(before time.Time) int {
+	purged := 0
+	for clid, orderContext := range t.orders {
+		if orderContext.Status.isActive() || !orderContext.StatusSince.Before(before) {
+			continue
+		}
+		delete(t.exchanges[orderContext.Order.Exchange][orderContext.Order.Symbol].orders, clid)
+		delete(t.orders, clid)
+		purged++
+	}
+	t.emit(Event{Kind: EventPurge, Time: before})
+	return purged
+}
+
+// TrimHistory drops the oldest fills of every order exceeding the limit set by WithMaxFillsPerOrder,
+// bounding the memory of long-lived orders filled many times. Trimming preserves the aggregates:
+// the executed amount and value of dropped fills are kept, so the aggregated report, remaining amount,
+// filled notional, average fill price and inventory are unchanged, as is the time to the first fill.
+// Windowed analytics like TurnoverWindow only see the kept fills. The order history is not trimmed,
+// as consecutive partial fills share a single history entry.
+// Returns the number of dropped fills, or 0 if no limit is set or the tracker is halted.
+func (t *Tracker) TrimHistory() int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
 
-	if orderContext.Status != OrderCanceling {
-		return fmt.Errorf("order status is not 'OrderCanceling' (clid %v, status '%s')",
-			clid, orderContext.Status)
+	if t.writable() != nil || t.maxFills <= 0 {
+		return 0
 	}
+	return t.trimHistory(t.maxFills)
+}
 
-	orderContext.Status = OrderUnplaced
-	return nil
+// trimHistory implements TrimHistory keeping at most limit fills per order, the guard should be held.
+// The limit is recorded in the event, so replay trims like the recording tracker.
+func (t *Tracker) trimHistory(limit int) int {
+	dropped := 0
+	for _, orderContext := range t.orders {
+		dropped += orderContext.trimFills(limit)
+	}
+	t.emit(Event{Kind: EventTrimHistory, Time: t.now(), Amount: uint64(limit)})
+	return dropped
 }
 
-// OrderFilled updates an order's state to reflect that it has been filled,
-// either fully or partially.
-// It accepts the order's client ID, the execution time, the executed amount, and the average price.
-// If multiple fills occur, it aggregates the executed amounts and recalculates the price
-// using a Volume Weighted Average Price (VWAP) calculation.
-// Returns an error if the order is not found.
-func (t *Tracker) OrderFilled(clid OrderClientID, time time.Time, executedAmount uint64, avgPrice uint64) error {
+// OrderPlacingBatch registers the orders as pending placement under a single guard acquisition.
+// It returns a slice with an error for each order in the same position, nil for placed orders.
+// A failed order, like a duplicate client ID within the batch or against existing orders,
+// does not abort placing the rest of the batch.
+func (t *Tracker) OrderPlacingBatch(orders []Order) []error {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
+
+	errs := make([]error, len(orders))
+	for i, order := range orders {
+		errs[i] = t.failed("OrderPlacingBatch", order.ClientID, t.orderPlacing(order))
+	}
+	return errs
+}
+
+// OrderPlaceConfirmed confirms that an order has been successfully placed.
+// It takes the order's client ID and the confirmation time as parameters.
+// A duplicate confirmation of an already placed order that is not older than the first one
+// is ignored without an error.
+// Returns the order status before the call and an error if the order is not found
+// or if the current status is not OrderPlacing.
+func (t *Tracker) OrderPlaceConfirmed(clid OrderClientID, time time.Time) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderPlaceConfirmed", clid, t.orderPlaceConfirmed(clid, time))
+}
+
+// orderPlaceConfirmed implements OrderPlaceConfirmed, the guard should be held.
+func (t *Tracker) orderPlaceConfirmed(clid OrderClientID, time time.Time) error {
+	return t.placeConfirmed(clid, time, EventPlaceConfirmed)
+}
+
+// placeConfirmed confirms the placement of the order recording it with the event kind,
+// EventPlaceConfirmed or EventPlaced, the guard should be held. Synchronous placements
+// recorded with EventPlaced are not acknowledged, so they add no acknowledgment latency sample.
+func (t *Tracker) placeConfirmed(clid OrderClientID, time time.Time, kind EventKind) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
 
 	orderContext := t.orders[clid]
 	if orderContext == nil {
-		return fmt.Errorf("order not found (clid %v)", clid)
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Status == OrderPlaced && !orderContext.PlacedTime.IsZero() &&
+		!time.Before(orderContext.PlacedTime) {
+		return nil // Duplicate acknowledgment
+	}
+	if err := checkTransition(orderContext, opPlaceConfirm, OrderPlaced); err != nil {
+		return err
 	}
 
-	orderContext.Status = OrderFilled
+	orderContext.LastReport.Kind = ReportPlaced
 	orderContext.LastReport.Time = time
+	t.setStatus(orderContext, OrderPlaced, time)
+	orderContext.PlacedTime = time
+	orderContext.record(OrderPlacing, time)
 
-	// Aggregating trades here with VWAP price
-	// Alternative is to store information about each trade
-	if orderContext.LastReport.Kind == ReportFilled {
-		vwap := (orderContext.LastReport.Amount*orderContext.LastReport.Price + executedAmount*avgPrice) / (orderContext.LastReport.Amount + executedAmount)
-		orderContext.LastReport.Price = vwap
-		orderContext.LastReport.Amount += executedAmount
-	} else { // Single trade
-		orderContext.LastReport.Kind = ReportFilled
-		orderContext.LastReport.Amount = executedAmount
-		orderContext.LastReport.Price = avgPrice
+	if kind == EventPlaceConfirmed {
+		stats := t.ackLatency[orderContext.Order.Exchange]
+		stats.add(time.Sub(orderContext.PlacingTime))
+		t.ackLatency[orderContext.Order.Exchange] = stats
 	}
-
+	t.stats.Placed++
+	t.emit(Event{Kind: kind, ClientID: clid, Time: time})
 	return nil
 }
 
-// GetOrderStatus retrieves the current state and details of an order.
-// It takes the order's client ID and pointers to an Order and an ExecutionReport,
-// which will be updated with the current order and its latest execution report.
-// Returns the current OrderStatus and an error if the order does not exist.
-func (t *Tracker) GetOrderStatus(clid OrderClientID, order *Order, executionReport *ExecutionReport) (OrderStatus, error) {
+// OrderRejected updates an order's state to indicate that it has been rejected.
+// It accepts the order's client ID, the time of rejection, and a reason message.
+// Returns the order status before the call, telling whether a placement, modification
+// or cancellation was rejected, and an error if the order is not found or if the status
+// does not allow for rejection.
+func (t *Tracker) OrderRejected(clid OrderClientID, time time.Time, reason string) (OrderStatus, error) {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderRejected", clid, t.orderRejected(clid, time, RejectUnknown, reason))
+}
+
+// OrderRejectedWithCode rejects an order like OrderRejected keeping the rejection code
+// in the ReportRejected report along with the reason. OrderRejected is OrderRejectedWithCode
+// with RejectUnknown.
+func (t *Tracker) OrderRejectedWithCode(clid OrderClientID, time time.Time, code RejectCode,
+	reason string) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderRejectedWithCode", clid, t.orderRejected(clid, time, code, reason))
+}
+
+// orderRejected implements OrderRejectedWithCode, the guard should be held.
+// It dispatches to the rejection of the pending placement, modification or cancellation.
+func (t *Tracker) orderRejected(clid OrderClientID, time time.Time, code RejectCode, reason string) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
 
 	orderContext := t.orders[clid]
 	if orderContext == nil {
-		return OrderUnplaced, fmt.Errorf("order not found (clid %v)", clid)
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
 	}
-	*order = orderContext.Order
-	*executionReport = orderContext.LastReport
-	return orderContext.Status, nil
+	return t.reject(clid, orderContext.Status, time, code, reason)
 }
 
-// PushQuote updates the market data for a specific symbol on a specific exchange.
-// It accepts the ExchangeID, SymbolID, bid price, and ask price as parameters.
-// If no market data exists for the exchange or symbol, new data is created.
-// The function also potentially trigger order movements based on the current spread.
-func (t *Tracker) PushQuote(exchangeID ExchangeID, symbolID SymbolID, bid uint64, ask uint64) {
+// RejectPlace rejects the pending placement of an order moving it from OrderPlacing
+// into OrderUnplaced with a ReportRejected report keeping the reason.
+// Returns an error if the order is not found or is not in the OrderPlacing state.
+func (t *Tracker) RejectPlace(clid OrderClientID, time time.Time, reason string) error {
 	t.guard.Lock()
-	defer t.guard.Unlock()
+	defer t.unlock()
+	return t.failed("RejectPlace", clid, t.reject(clid, OrderPlacing, time, RejectUnknown, reason))
+}
 
-	exchange := t.exchanges[exchangeID]
-	if exchange == nil {
-		exchange = make(map[SymbolID]marketData)
-		t.exchanges[exchangeID] = exchange
-	}
-	symbolContext := exchange[symbolID]
-	symbolContext.bid = bid
-	symbolContext.ask = ask
-	exchange[symbolID] = symbolContext
+// RejectModify rejects the pending modification of an order moving it from OrderModifying
+// back into OrderPlaced with a ReportRejected report keeping the reason. The order price is unchanged.
+// Returns an error if the order is not found or is not in the OrderModifying state.
+func (t *Tracker) RejectModify(clid OrderClientID, time time.Time, reason string) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("RejectModify", clid, t.reject(clid, OrderModifying, time, RejectUnknown, reason))
+}
 
-	/// TODO: Get signals to move order based on current spread
+// RejectCancel rejects the pending cancellation of an order moving it from OrderCanceling
+// back into OrderPlaced with a ReportRejected report keeping the reason. An order canceled
+// while modifying returns into OrderModifying, so the pending modification can still be confirmed.
+// Returns an error if the order is not found or is not in the OrderCanceling state.
+func (t *Tracker) RejectCancel(clid OrderClientID, time time.Time, reason string) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("RejectCancel", clid, t.reject(clid, OrderCanceling, time, RejectUnknown, reason))
+}
+
+// reject rejects the request pending in the expected status, the guard should be held.
+func (t *Tracker) reject(clid OrderClientID, expected OrderStatus, time time.Time, code RejectCode, reason string) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Status != expected {
+		return fmt.Errorf("%w: no request pending in status '%s' (clid %v, status '%s')",
+			ErrInvalidTransition, expected, clid, orderContext.Status)
+	}
+	to := OrderPlaced
+	switch {
+	case expected == OrderPlacing:
+		to = OrderUnplaced
+	case expected == OrderCanceling && orderContext.MovePending:
+		// The modification sent before the rejected cancellation is still pending
+		to = OrderModifying
+	}
+	if err := checkTransition(orderContext, opReject, to); err != nil {
+		return err
+	}
+	orderContext.MovePending = false
+
+	orderContext.LastReport.Kind = ReportRejected
+	orderContext.LastReport.Time = time
+	orderContext.LastReport.Message = reason
+	orderContext.LastReport.RejectCode = code
+	t.setStatus(orderContext, to, time)
+	orderContext.record(expected, time)
+	t.stats.Rejected++
+	t.pendingRejects = append(t.pendingRejects, rejection{clid: clid, reason: reason, from: expected})
+	t.emit(Event{Kind: EventRejected, ClientID: clid, Time: time, Reason: reason, RejectCode: code})
+	return nil
+}
+
+// OrderMoving initiates the order price modification.
+// It accepts the order's client ID.
+// Returns an error if the order is not found or if the order status is not OrderPlaced.
+func (t *Tracker) OrderMoving(clid OrderClientID) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("OrderMoving", clid, t.orderMoving(clid))
+}
+
+// orderMoving implements OrderMoving, the guard should be held.
+func (t *Tracker) orderMoving(clid OrderClientID) error {
+	orderContext, err := t.checkMoving(clid)
+	if err != nil {
+		return err
+	}
+	now := t.now()
+	t.setStatus(orderContext, OrderModifying, now)
+	orderContext.LastReport.Kind = ReportNone
+	orderContext.record(OrderPlaced, now)
+	t.emit(Event{Kind: EventMoving, ClientID: clid, Time: now})
+	return nil
+}
+
+// CanMove returns the error OrderMoving would return for the order without changing its state.
+func (t *Tracker) CanMove(clid OrderClientID) error {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	_, err := t.checkMoving(clid)
+	return err
+}
+
+// checkMoving validates moving the order and returns its context, the guard should be held.
+func (t *Tracker) checkMoving(clid OrderClientID) (*orderContext, error) {
+	if err := t.writable(); err != nil {
+		return nil, err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return nil, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if err := checkTransition(orderContext, opMove, OrderModifying); err != nil {
+		return nil, err
+	}
+	return orderContext, nil
+}
+
+// OrderMoveConfirmed confirms a previously initiated order modification.
+// It takes the order's client ID, the confirmation time, and the new price.
+// A duplicate confirmation of the same price that is not older than the first one
+// is ignored without an error. A confirmation of the modification pending when the order
+// was canceled updates the order price but keeps the order in OrderCanceling.
+// Returns the order status before the call and an error if the order is not found
+// or if the order is not in the OrderModifying state.
+func (t *Tracker) OrderMoveConfirmed(clid OrderClientID, time time.Time, price Price) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderMoveConfirmed", clid, t.orderMoveConfirmed(clid, time, price))
+}
+
+// orderMoveConfirmed implements OrderMoveConfirmed, the guard should be held.
+func (t *Tracker) orderMoveConfirmed(clid OrderClientID, time time.Time, price Price) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Status == OrderPlaced && orderContext.LastReport.Kind == ReportModified &&
+		orderContext.LastReport.Price == price && !time.Before(orderContext.LastReport.Time) {
+		return nil // Duplicate acknowledgment
+	}
+	if orderContext.Status == OrderCanceling {
+		if !orderContext.MovePending {
+			return fmt.Errorf("%w: no modification pending (clid %v, status '%s')",
+				ErrInvalidTransition, clid, orderContext.Status)
+		}
+		if err := checkTransition(orderContext, opMoveConfirm, OrderCanceling); err != nil {
+			return err
+		}
+		// Cancellation overrides the modification, only the resting price is kept
+		orderContext.MovePending = false
+		orderContext.Order.Price = price
+		t.emit(Event{Kind: EventMoveConfirmed, ClientID: clid, Time: time, Price: price})
+		return nil
+	}
+
+	if err := checkTransition(orderContext, opMoveConfirm, OrderPlaced); err != nil {
+		return err
+	}
+
+	orderContext.LastReport.Kind = ReportModified
+	orderContext.LastReport.Time = time
+	orderContext.LastReport.Price = price
+	t.setStatus(orderContext, OrderPlaced, time)
+	orderContext.Order.Price = price
+	orderContext.record(OrderModifying, time)
+	t.stats.Moved++
+	t.emit(Event{Kind: EventMoveConfirmed, ClientID: clid, Time: time, Price: price})
+	return nil
+}
+
+// OrderCancelling initiates the cancellation process for an active order.
+// It takes the order's client ID and validates that the order exists and is in the OrderPlaced
+// or OrderModifying state. An order canceled while modifying stays in OrderCanceling
+// when the modification is confirmed later.
+// Returns an error if the order does not exist or is not in an appropriate state for cancellation.
+func (t *Tracker) OrderCancelling(clid OrderClientID) error {
+	t.guard.Lock()
+	defer t.unlock()
+	return t.failed("OrderCancelling", clid, t.orderCancelling(clid))
+}
+
+// orderCancelling implements OrderCancelling, the guard should be held.
+func (t *Tracker) orderCancelling(clid OrderClientID) error {
+	orderContext, err := t.checkCancelling(clid)
+	if err != nil {
+		return err
+	}
+	t.cancel(orderContext, t.now())
+	return nil
+}
+
+// cancel moves the order into OrderCanceling remembering a pending modification,
+// the guard should be held.
+func (t *Tracker) cancel(c *orderContext, now time.Time) {
+	from := c.Status
+	c.MovePending = from == OrderModifying
+	t.setStatus(c, OrderCanceling, now)
+	c.LastReport.Kind = ReportNone
+	c.record(from, now)
+	t.emit(Event{Kind: EventCancelling, ClientID: c.Order.ClientID, Time: now})
+}
+
+// CanCancel returns the error OrderCancelling would return for the order without changing its state.
+func (t *Tracker) CanCancel(clid OrderClientID) error {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	_, err := t.checkCancelling(clid)
+	return err
+}
+
+// checkCancelling validates cancelling the order and returns its context, the guard should be held.
+func (t *Tracker) checkCancelling(clid OrderClientID) (*orderContext, error) {
+	if err := t.writable(); err != nil {
+		return nil, err
+	}
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return nil, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if err := checkTransition(orderContext, opCancel, OrderCanceling); err != nil {
+		return nil, err
+	}
+	return orderContext, nil
+}
+
+// CancelAll moves every order in the OrderPlaced or OrderModifying state into OrderCanceling
+// like OrderCancelling under a single guard acquisition and returns the sorted client IDs
+// of transitioned orders, so the caller can send the actual cancel requests.
+// Orders in other states are skipped.
+// Returns nil while the tracker is halted.
+func (t *Tracker) CancelAll() []OrderClientID {
+	t.guard.Lock()
+	defer t.unlock()
+
+	if t.writable() != nil {
+		return nil
+	}
+
+	return t.cancelMatching(t.orders, func(*orderContext) bool { return true })
+}
+
+// cancelMatching cancels the orders accepting cancellation and matching the filter
+// in client ID order and returns their client IDs, the guard should be held.
+func (t *Tracker) cancelMatching(orders map[OrderClientID]*orderContext, match func(*orderContext) bool) []OrderClientID {
+	var canceling []OrderClientID
+	for clid, orderContext := range orders {
+		if accepts(orderContext.Status, opCancel) && match(orderContext) {
+			canceling = append(canceling, clid)
+		}
+	}
+	slices.Sort(canceling)
+
+	now := t.now()
+	for _, clid := range canceling {
+		t.cancel(orders[clid], now)
+	}
+	return canceling
+}
+
+// CancelSymbol moves every order in the OrderPlaced or OrderModifying state on the symbol of the exchange
+// into OrderCanceling under a single guard acquisition and returns the client IDs of
+// transitioned orders like CancelAll. Orders in other states are skipped.
+// Returns nil while the tracker is halted.
+func (t *Tracker) CancelSymbol(exchange ExchangeID, symbol SymbolID) []OrderClientID {
+	t.guard.Lock()
+	defer t.unlock()
+
+	if t.writable() != nil {
+		return nil
+	}
+
+	return t.cancelMatching(t.exchanges[exchange][symbol].orders, func(*orderContext) bool { return true })
+}
+
+// CancelByTag moves every order in the OrderPlaced or OrderModifying state with the given tag into OrderCanceling
+// under a single guard acquisition and returns the client IDs of transitioned orders like CancelAll.
+// Orders in other states are skipped. Returns nil while the tracker is halted.
+func (t *Tracker) CancelByTag(tag string) []OrderClientID {
+	t.guard.Lock()
+	defer t.unlock()
+
+	if t.writable() != nil {
+		return nil
+	}
+
+	return t.cancelMatching(t.orders, func(c *orderContext) bool { return c.Order.Tag == tag })
+}
+
+// ExpireOrders drives order lifecycle from wall-clock time: it moves resting GTD orders
+// with ExpiresAt not after now and placed IOC orders without fills into OrderExpired
+// with a ReportExpired report. Returns the client IDs of expired orders,
+// or nil while the tracker is halted.
+func (t *Tracker) ExpireOrders(now time.Time) []OrderClientID {
+	t.guard.Lock()
+	defer t.unlock()
+
+	if t.writable() != nil {
+		return nil
+	}
+
+	var expired []OrderClientID
+	for clid, orderContext := range t.orders {
+		order := &orderContext.Order
+		switch {
+		case order.TimeInForce == TifGTD && accepts(orderContext.Status, opExpire) &&
+			!order.ExpiresAt.IsZero() && !now.Before(order.ExpiresAt):
+		case order.TimeInForce == TifIOC && orderContext.Status == OrderPlaced &&
+			orderContext.FilledAmount == 0:
+		default:
+			continue
+		}
+		t.expire(orderContext, now)
+		expired = append(expired, clid)
+	}
+	return expired
+}
+
+// OrderExpired moves a resting order into OrderExpired with a ReportExpired report,
+// when the exchange reports that the order expired.
+// Returns the order status before the call and an error if the order is not found
+// or if the order is not in OrderPlaced, OrderModifying or OrderCanceling state.
+func (t *Tracker) OrderExpired(clid OrderClientID, time time.Time) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderExpired", clid, t.orderExpired(clid, time))
+}
+
+// orderExpired implements OrderExpired, the guard should be held.
+func (t *Tracker) orderExpired(clid OrderClientID, time time.Time) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if err := checkTransition(orderContext, opExpire, OrderExpired); err != nil {
+		return err
+	}
+	t.expire(orderContext, time)
+	return nil
+}
+
+// expire moves the order into OrderExpired and queues the notification of OnExpire handlers,
+// the guard should be held.
+func (t *Tracker) expire(orderContext *orderContext, time time.Time) {
+	from := orderContext.Status
+	t.setStatus(orderContext, OrderExpired, time)
+	orderContext.LastReport = ExecutionReport{
+		Kind: ReportExpired,
+		Time: time,
+	}
+	orderContext.record(from, time)
+	t.stats.Expired++
+	t.pendingExpires = append(t.pendingExpires, orderContext.Order.ClientID)
+	t.emit(Event{Kind: EventExpired, ClientID: orderContext.Order.ClientID, Time: time})
+}
+
+// OrderCancelConfirmed finalizes an order cancellation.
+// It takes the order's client ID and the confirmation time as parameters.
+// A duplicate confirmation of an already canceled order that is not older than the first one
+// is ignored without an error.
+// Returns the order status before the call and an error if the order is not found
+// or if the order is not in the OrderCanceling state.
+func (t *Tracker) OrderCancelConfirmed(clid OrderClientID, time time.Time) (OrderStatus, error) {
+	return t.OrderCancelConfirmedWithReason(clid, time, "")
+}
+
+// OrderCancelConfirmedWithReason finalizes an order cancellation like OrderCancelConfirmed
+// and keeps the reason of the cancellation (user request, IOC timeout, risk kill, etc.)
+// in the execution report message.
+func (t *Tracker) OrderCancelConfirmedWithReason(clid OrderClientID, time time.Time, reason string) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderCancelConfirmedWithReason", clid, t.orderCancelConfirmedWithReason(clid, time, reason))
+}
+
+// orderCancelConfirmedWithReason implements OrderCancelConfirmedWithReason, the guard should be held.
+func (t *Tracker) orderCancelConfirmedWithReason(clid OrderClientID, time time.Time, reason string) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Status == OrderUnplaced && orderContext.LastReport.Kind == ReportCanceled &&
+		!time.Before(orderContext.LastReport.Time) {
+		return nil // Duplicate acknowledgment
+	}
+
+	if err := checkTransition(orderContext, opCancelConfirm, OrderUnplaced); err != nil {
+		return err
+	}
+
+	orderContext.LastReport.Kind = ReportCanceled
+	orderContext.LastReport.Time = time
+	orderContext.LastReport.Message = reason
+	t.setStatus(orderContext, OrderUnplaced, time)
+	orderContext.record(OrderCanceling, time)
+	t.stats.Canceled++
+	t.emit(Event{Kind: EventCancelConfirmed, ClientID: clid, Time: time, Reason: reason})
+	return nil
+}
+
+// OrderPartialCancelConfirmed applies a cancellation of a part of the order remaining amount,
+// reducing the order amount by canceledAmount. The order stays in OrderPlaced if some amount
+// remains, otherwise it moves into OrderUnplaced. The report is ReportCanceled with the canceled amount.
+// It is accepted for orders in OrderPlaced or OrderCanceling state.
+// Returns the order status before the call and an error if the order is not found,
+// is in another state, or the canceled amount is zero or exceeds the remaining amount.
+func (t *Tracker) OrderPartialCancelConfirmed(clid OrderClientID, time time.Time, canceledAmount uint64) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	from := t.status(clid)
+	return from, t.failed("OrderPartialCancelConfirmed", clid, t.orderPartialCancelConfirmed(clid, time, canceledAmount))
+}
+
+// orderPartialCancelConfirmed implements OrderPartialCancelConfirmed, the guard should be held.
+func (t *Tracker) orderPartialCancelConfirmed(clid OrderClientID, time time.Time, canceledAmount uint64) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	from := orderContext.Status
+	remaining := orderContext.remaining()
+	to := OrderPlaced
+	if canceledAmount == remaining {
+		to = OrderUnplaced
+	}
+	if err := checkTransition(orderContext, opPartialCancel, to); err != nil {
+		return err
+	}
+	if canceledAmount == 0 || canceledAmount > remaining {
+		return fmt.Errorf("canceled amount %d is out of remaining amount %d (clid %v)",
+			canceledAmount, remaining, clid)
+	}
+
+	orderContext.Order.Amount -= canceledAmount
+	orderContext.LastReport = ExecutionReport{
+		Kind:   ReportCanceled,
+		Time:   time,
+		Amount: canceledAmount,
+		Price:  orderContext.Order.Price,
+	}
+	t.setStatus(orderContext, to, time)
+	if to == OrderUnplaced {
+		t.stats.Canceled++
+	}
+	orderContext.record(from, time)
+	t.emit(Event{Kind: EventPartialCancelConfirmed, ClientID: clid, Time: time, Amount: canceledAmount})
+	return nil
+}
+
+// OrderFilled updates an order's state to reflect that it has been filled,
+// either fully or partially.
+// It accepts the order's client ID, the execution time, the executed amount, and the average price.
+// If multiple fills occur, it aggregates the executed amounts and recalculates the price
+// using a Volume Weighted Average Price (VWAP) calculation.
+// The order moves to OrderFilled only when the executed amount reaches the order amount,
+// a partial fill keeps the order state, so a resting order stays placed and
+// a pending modification or cancellation can still be confirmed or rejected.
+// With WithFillHistory each fill is also kept in the order fill history for windowed analytics.
+// Returns true if the order is filled completely, so no more fills are expected.
+// Returns an error if the order is not found, ErrInvalidFill if the executed amount is zero
+// or ErrOverfill if it exceeds the remaining amount of the order.
+func (t *Tracker) OrderFilled(clid OrderClientID, time time.Time, executedAmount uint64, avgPrice Price) (bool, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	complete, err := t.orderFilled(clid, time, executedAmount, avgPrice)
+	return complete, t.failed("OrderFilled", clid, err)
+}
+
+// orderFilled implements OrderFilled, the guard should be held.
+func (t *Tracker) orderFilled(clid OrderClientID, time time.Time, executedAmount uint64, avgPrice Price) (bool, error) {
+	if err := t.writable(); err != nil {
+		return false, err
+	}
+
+	orderContext, err := t.fillable(clid, executedAmount)
+	if err != nil {
+		return false, err
+	}
+
+	complete := t.fill(orderContext, Fill{
+		Time:   time,
+		Amount: executedAmount,
+		Price:  avgPrice,
+	})
+	t.stats.Filled++
+	t.pendingFills = append(t.pendingFills, FillEvent{ClientID: clid, Time: time, Amount: executedAmount, Price: avgPrice})
+	t.emit(Event{Kind: EventFilled, ClientID: clid, Time: time, Amount: executedAmount, Price: avgPrice})
+	return complete, nil
+}
+
+// OrderFilledWithTradeID applies a fill like OrderFilled, but deduplicates fills by the exchange
+// trade ID: a fill with a trade ID already applied to the order is ignored. This protects
+// against double counting when fill messages are delivered at least once.
+// A fill with an empty trade ID can't be deduplicated, so it is always applied.
+// Returns true if the fill was newly applied and false if it was a duplicate.
+// Returns an error if the order is not found.
+func (t *Tracker) OrderFilledWithTradeID(clid OrderClientID, time time.Time, tradeID string,
+	executedAmount uint64, avgPrice Price) (bool, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	applied, err := t.orderFilledWithTradeID(clid, time, tradeID, executedAmount, avgPrice)
+	return applied, t.failed("OrderFilledWithTradeID", clid, err)
+}
+
+// orderFilledWithTradeID implements OrderFilledWithTradeID, the guard should be held.
+func (t *Tracker) orderFilledWithTradeID(clid OrderClientID, time time.Time, tradeID string,
+	executedAmount uint64, avgPrice Price) (bool, error) {
+	if err := t.writable(); err != nil {
+		return false, err
+	}
+
+	orderContext, err := t.fillable(clid, executedAmount)
+	if err != nil {
+		return false, err
+	}
+	if tradeID != "" {
+		if _, seen := orderContext.TradeIDs[tradeID]; seen {
+			return false, nil
+		}
+		if orderContext.TradeIDs == nil {
+			orderContext.TradeIDs = make(map[string]struct{})
+		}
+		orderContext.TradeIDs[tradeID] = struct{}{}
+	}
+
+	t.fill(orderContext, Fill{
+		Time:    time,
+		TradeID: tradeID,
+		Amount:  executedAmount,
+		Price:   avgPrice,
+	})
+	t.stats.Filled++
+	t.pendingFills = append(t.pendingFills, FillEvent{ClientID: clid, Time: time, Amount: executedAmount, Price: avgPrice})
+	t.emit(Event{Kind: EventFilledWithTradeID, ClientID: clid, Time: time, TradeID: tradeID,
+		Amount: executedAmount, Price: avgPrice})
+	return true, nil
+}
+
+// OrderFilledWithFee applies a fill like OrderFilled and adds the fee charged for the fill
+// to the order fees and to the fee of the aggregated report. A negative fee is a rebate.
+// Returns true if the order is filled completely, so no more fills are expected.
+// Returns an error if the order is not found.
+func (t *Tracker) OrderFilledWithFee(clid OrderClientID, time time.Time, executedAmount uint64,
+	avgPrice Price, fee int64) (bool, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	complete, err := t.orderFilledWithFee(clid, time, executedAmount, avgPrice, fee)
+	return complete, t.failed("OrderFilledWithFee", clid, err)
+}
+
+// orderFilledWithFee implements OrderFilledWithFee, the guard should be held.
+func (t *Tracker) orderFilledWithFee(clid OrderClientID, time time.Time, executedAmount uint64,
+	avgPrice Price, fee int64) (bool, error) {
+	if err := t.writable(); err != nil {
+		return false, err
+	}
+
+	orderContext, err := t.fillable(clid, executedAmount)
+	if err != nil {
+		return false, err
+	}
+
+	complete := t.fill(orderContext, Fill{
+		Time:   time,
+		Amount: executedAmount,
+		Price:  avgPrice,
+		Fee:    fee,
+	})
+	orderContext.Fees += fee
+	t.stats.Filled++
+	t.pendingFills = append(t.pendingFills, FillEvent{ClientID: clid, Time: time, Amount: executedAmount, Price: avgPrice})
+	t.emit(Event{Kind: EventFilledWithFee, ClientID: clid, Time: time, Amount: executedAmount,
+		Price: avgPrice, Fee: fee})
+	return complete, nil
+}
+
+// fillable returns the order to apply a fill of the amount to, the guard should be held.
+// Returns an error if the order is not found, the amount is zero or exceeds the remaining amount,
+// with WithStrictFills the order is not confirmed placed, or the fill would move the order
+// along an edge missing in the transition table.
+func (t *Tracker) fillable(clid OrderClientID, amount uint64) (*orderContext, error) {
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return nil, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if amount == 0 {
+		return nil, fmt.Errorf("%w: amount is zero (clid %v)", ErrInvalidFill, clid)
+	}
+	if t.strictFills && (orderContext.Status == OrderUnplaced || orderContext.Status == OrderPlacing) {
+		return nil, fmt.Errorf("%w (clid %v, status '%s')", ErrFillNotPlaced, clid, orderContext.Status)
+	}
+	remaining := orderContext.remaining()
+	if amount > remaining {
+		return nil, fmt.Errorf("%w: fill of %d, remaining %d (clid %v)", ErrOverfill, amount, remaining, clid)
+	}
+	to := orderContext.Status
+	if amount == remaining {
+		to = OrderFilled
+	}
+	if err := checkTransition(orderContext, opFill, to); err != nil {
+		return nil, err
+	}
+	return orderContext, nil
+}
+
+// fill applies the fill to the order and marks it as filled once the order amount is executed,
+// the guard should be held. The aggregated report price is rounded according to the rounding mode.
+// Returns true if the order is filled completely.
+func (t *Tracker) fill(c *orderContext, fill Fill) bool {
+	from := c.Status
+	value := mul64(fill.Amount, uint64(fill.Price))
+	if t.fillHistory {
+		c.Fills = append(c.Fills, fill)
+	} else {
+		c.Trimmed.add(fill)
+	}
+	c.FilledAmount += fill.Amount
+	c.FilledValue = c.FilledValue.add(value)
+	c.Improvement = c.Improvement.add(c.improvement(fill))
+	// Partially filled order keeps its status, so modification or cancellation
+	// in flight stays pending and resting order stays placed
+	if c.remaining() == 0 {
+		t.setStatus(c, OrderFilled, fill.Time)
+	}
+	c.LastReport.Time = fill.Time
+
+	// Aggregating trades here with VWAP price computed from the exact value of aggregated trades,
+	// individual trades are kept in the fill history with WithFillHistory
+	if c.LastReport.Kind == ReportFilled {
+		c.ReportValue = c.ReportValue.add(value)
+		c.LastReport.Amount += fill.Amount
+		c.LastReport.Fee += fill.Fee
+		if t.rounding == VWAPRoundHalfUp {
+			c.LastReport.Price = Price(c.ReportValue.divRound64(c.LastReport.Amount))
+		} else {
+			c.LastReport.Price = Price(c.ReportValue.div64(c.LastReport.Amount))
+		}
+	} else { // Single trade
+		c.ReportValue = value
+		c.LastReport.Kind = ReportFilled
+		c.LastReport.Amount = fill.Amount
+		c.LastReport.Price = fill.Price
+		c.LastReport.Fee = fill.Fee
+	}
+	c.recordFill(from, fill.Time)
+	return c.Status == OrderFilled
+}
+
+// GetOrderStatus retrieves the current state and details of an order.
+// It takes the order's client ID and pointers to an Order and an ExecutionReport,
+// which will be updated with the current order and its latest execution report.
+// Either pointer may be nil to skip copying it.
+// Returns the current OrderStatus and an error if the order does not exist.
+func (t *Tracker) GetOrderStatus(clid OrderClientID, order *Order, executionReport *ExecutionReport) (OrderStatus, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return OrderUnplaced, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if order != nil {
+		*order = orderContext.Order
+	}
+	if executionReport != nil {
+		*executionReport = orderContext.LastReport
+	}
+	return orderContext.Status, nil
+}
+
+// GetOrderStatusString returns the current status of an order as text, like "Placed", for logging.
+// Returns an error if the order does not exist.
+func (t *Tracker) GetOrderStatusString(clid OrderClientID) (string, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return "", fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.Status.String(), nil
+}
+
+// GetCurrentStatus returns the current status of an order along with copies of the order
+// and its latest execution report. It is a value-returning alternative to GetOrderStatus.
+// Returns an error if the order does not exist.
+func (t *Tracker) GetCurrentStatus(clid OrderClientID) (OrderStatus, Order, ExecutionReport, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return OrderUnplaced, Order{}, ExecutionReport{}, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.Status, orderContext.Order, orderContext.LastReport, nil
+}
+
+// GetOrderStatuses returns the current statuses of the given orders under a single guard acquisition.
+// Unknown client IDs are omitted from the result.
+func (t *Tracker) GetOrderStatuses(clids []OrderClientID) map[OrderClientID]OrderStatus {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	statuses := make(map[OrderClientID]OrderStatus, len(clids))
+	for _, clid := range clids {
+		if orderContext := t.orders[clid]; orderContext != nil {
+			statuses[clid] = orderContext.Status
+		}
+	}
+	return statuses
+}
+
+// GetExecutionReport returns a copy of the latest execution report of an order.
+// Returns an error if the order does not exist.
+func (t *Tracker) GetExecutionReport(clid OrderClientID) (ExecutionReport, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return ExecutionReport{}, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.LastReport, nil
+}
+
+// GetOrder returns a copy of the order parameters without its status and execution report.
+// Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) GetOrder(clid OrderClientID) (Order, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return Order{}, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.Order, nil
+}
+
+// GetOriginalOrder returns a copy of the order parameters as requested at placement,
+// unlike GetOrder not changed by confirmed modifications, for comparing executions
+// against the original intent. Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) GetOriginalOrder(clid OrderClientID) (Order, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return Order{}, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.OriginalOrder, nil
+}
+
+// GetFees returns the total fees of the order fills, negative if rebates exceed fees.
+// Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) GetFees(clid OrderClientID) (int64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.Fees, nil
+}
+
+// LastUpdateTime returns the time of the most recent transition applied to an order.
+// It is the time of the last history entry, which is the local time for transitions
+// without an exchange report such as OrderMoving. Falls back to the last report time
+// and then to the time the order entered its current status if the history is empty.
+// Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) LastUpdateTime(clid OrderClientID) (time.Time, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return time.Time{}, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if n := len(orderContext.History); n > 0 {
+		return orderContext.History[n-1].Time, nil
+	}
+	if !orderContext.LastReport.Time.IsZero() {
+		return orderContext.LastReport.Time, nil
+	}
+	return orderContext.StatusSince, nil
+}
+
+// PushQuote updates the market data for a specific symbol on a specific exchange.
+// It accepts the ExchangeID, SymbolID, bid price, and ask price as parameters.
+// If no market data exists for the exchange or symbol, new data is created.
+// Returns the signals of placed orders on the symbol to move, see QuoteSignals.
+// The quote sizes are set to zero, use PushQuoteWithSizes to keep them.
+// With validation enabled, returns ErrInvalidQuote for ExchangeNone or an empty symbol.
+func (t *Tracker) PushQuote(exchangeID ExchangeID, symbolID SymbolID, bid Price, ask Price) (QuoteSignals, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	signals, err := t.pushQuote(exchangeID, symbolID, bid, ask, 0, 0)
+	return signals, t.failed("PushQuote", "", err)
+}
+
+// PushQuoteWithSizes updates the market data like PushQuote and also keeps
+// the sizes available at the bid and ask prices.
+func (t *Tracker) PushQuoteWithSizes(exchangeID ExchangeID, symbolID SymbolID, bid Price, ask Price,
+	bidSize uint64, askSize uint64) (QuoteSignals, error) {
+	t.guard.Lock()
+	defer t.unlock()
+	signals, err := t.pushQuote(exchangeID, symbolID, bid, ask, bidSize, askSize)
+	return signals, t.failed("PushQuoteWithSizes", "", err)
+}
+
+// SymbolQuote is a quote of a symbol in a batch pushed with PushQuotes.
+type SymbolQuote struct {
+	Symbol SymbolID
+	Bid    Price
+	Ask    Price
+}
+
+// PushQuotes updates the market data of the symbols on the exchange like PushQuote
+// under a single guard acquisition, which is cheaper for snapshots of many symbols.
+// Returns the signals of every quote at its index in the batch. Quotes PushQuote would fail on,
+// like invalid quotes with validation enabled, are skipped with empty signals
+// and reported to the WithErrorLogger function.
+func (t *Tracker) PushQuotes(exchangeID ExchangeID, quotes []SymbolQuote) []QuoteSignals {
+	t.guard.Lock()
+	defer t.unlock()
+
+	signals := make([]QuoteSignals, len(quotes))
+	for i, quote := range quotes {
+		var err error
+		signals[i], err = t.pushQuote(exchangeID, quote.Symbol, quote.Bid, quote.Ask, 0, 0)
+		_ = t.failed("PushQuotes", "", err)
+	}
+	return signals
+}
+
+// pushQuote implements PushQuote and PushQuoteWithSizes, the guard should be held.
+func (t *Tracker) pushQuote(exchangeID ExchangeID, symbolID SymbolID, bid Price, ask Price,
+	bidSize uint64, askSize uint64) (QuoteSignals, error) {
+	// Market data keeps flowing while halted, but not after Close
+	if t.closed {
+		return QuoteSignals{}, ErrTrackerClosed
+	}
+	if t.validation {
+		if exchangeID == ExchangeNone {
+			return QuoteSignals{}, fmt.Errorf("%w: exchange is not set (symbol %v)", ErrInvalidQuote, symbolID)
+		}
+		if symbolID == "" {
+			return QuoteSignals{}, fmt.Errorf("%w: symbol is empty (exchange %v)", ErrInvalidQuote, exchangeID)
+		}
+	}
+
+	exchange := t.exchanges[exchangeID]
+	if exchange == nil {
+		exchange = make(map[SymbolID]marketData)
+		t.exchanges[exchangeID] = exchange
+	}
+	symbolContext := exchange[symbolID]
+	symbolContext.bid = bid
+	symbolContext.ask = ask
+	symbolContext.bidSize = bidSize
+	symbolContext.askSize = askSize
+	symbolContext.hasQuote = true
+	exchange[symbolID] = symbolContext
+	t.emit(Event{Kind: EventQuote, Time: t.now(), Exchange: exchangeID, Symbol: symbolID, Bid: bid, Ask: ask,
+		BidSize: bidSize, AskSize: askSize})
+	return symbolContext.signals(t.moveBps, t.specs[exchangeID][symbolID].TickSize), nil
+}
+
+// GetMarketQuote returns the latest quote of the symbol on the exchange.
+// The boolean result is false if no quote was pushed for the symbol.
+func (t *Tracker) GetMarketQuote(exchange ExchangeID, symbol SymbolID) (MarketQuote, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	symbolContext := t.exchanges[exchange][symbol]
+	if !symbolContext.hasQuote {
+		return MarketQuote{}, false
+	}
+	return MarketQuote{
+		Bid:     symbolContext.bid,
+		Ask:     symbolContext.ask,
+		BidSize: symbolContext.bidSize,
+		AskSize: symbolContext.askSize,
+	}, true
+}
+
+// MidPrice returns the price halfway between the latest bid and ask of the symbol on the exchange,
+// rounded down. The boolean result is false if no quote was pushed for the symbol.
+func (t *Tracker) MidPrice(exchange ExchangeID, symbol SymbolID) (Price, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	symbolContext := t.exchanges[exchange][symbol]
+	return symbolContext.mid()
+}
+
+// SpreadBps returns the spread between the latest ask and bid of the symbol on the exchange
+// in basis points of the mid price, rounded down. The boolean result is false if no quote
+// was pushed for the symbol, the quote is crossed or the mid price is zero.
+func (t *Tracker) SpreadBps(exchange ExchangeID, symbol SymbolID) (uint64, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	symbolContext := t.exchanges[exchange][symbol]
+	return symbolContext.spreadBps()
+}
+
+// SymbolView is a view of the market data and active orders of a symbol on an exchange
+// taken under a single guard acquisition. Mid and SpreadBps are derived from the quote
+// like MidPrice and SpreadBps and are zero if they are not available.
+type SymbolView struct {
+	Quote     MarketQuote
+	HasQuote  bool
+	Mid       Price
+	SpreadBps uint64
+	Orders    []SymbolOrder
+}
+
+// SymbolOrder is an active order in a SymbolView with its status and remaining amount.
+type SymbolOrder struct {
+	Status    OrderStatus
+	Order     Order
+	Remaining uint64
+}
+
+// SymbolView returns the view of the symbol on the exchange with active orders sorted by client ID.
+// The boolean result is false if neither a quote nor an order was pushed for the symbol.
+func (t *Tracker) SymbolView(exchange ExchangeID, symbol SymbolID) (SymbolView, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	symbolContext, ok := t.exchanges[exchange][symbol]
+	if !ok {
+		return SymbolView{}, false
+	}
+	view := SymbolView{
+		Quote: MarketQuote{
+			Bid:     symbolContext.bid,
+			Ask:     symbolContext.ask,
+			BidSize: symbolContext.bidSize,
+			AskSize: symbolContext.askSize,
+		},
+		HasQuote: symbolContext.hasQuote,
+	}
+	view.Mid, _ = symbolContext.mid()
+	view.SpreadBps, _ = symbolContext.spreadBps()
+	for _, orderContext := range symbolContext.orders {
+		if !orderContext.Status.isActive() {
+			continue
+		}
+		view.Orders = append(view.Orders, SymbolOrder{
+			Status:    orderContext.Status,
+			Order:     orderContext.Order,
+			Remaining: orderContext.remaining(),
+		})
+	}
+	slices.SortFunc(view.Orders, func(a, b SymbolOrder) int {
+		return strings.Compare(string(a.Order.ClientID), string(b.Order.ClientID))
+	})
+	return view, true
+}
+
+// CrossPair is a pair of our own active orders on a symbol that would trade with each other,
+// the buy order price is not less than the sell order price.
+type CrossPair struct {
+	Buy  OrderClientID
+	Sell OrderClientID
+}
+
+// DetectSelfCross returns the pairs of active limit orders on the exchange and symbol crossing
+// each other, sorted by the buy and then the sell client ID. Market orders and orders without
+// a side are not considered. Returns nil if no orders cross.
+func (t *Tracker) DetectSelfCross(exchange ExchangeID, symbol SymbolID) []CrossPair {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var buys, sells []*Order
+	for _, orderContext := range t.exchanges[exchange][symbol].orders {
+		order := &orderContext.Order
+		if !orderContext.Status.isActive() || order.Type != TypeLimit {
+			continue
+		}
+		switch order.Side {
+		case SideBuy:
+			buys = append(buys, order)
+		case SideSell:
+			sells = append(sells, order)
+		}
+	}
+	var pairs []CrossPair
+	for _, buy := range buys {
+		for _, sell := range sells {
+			if buy.Price >= sell.Price {
+				pairs = append(pairs, CrossPair{Buy: buy.ClientID, Sell: sell.ClientID})
+			}
+		}
+	}
+	slices.SortFunc(pairs, func(a, b CrossPair) int {
+		return cmp.Or(strings.Compare(string(a.Buy), string(b.Buy)), strings.Compare(string(a.Sell), string(b.Sell)))
+	})
+	return pairs
+}
+
+// TrackedSymbols returns the sorted symbols of every exchange with either a quote
+// or a tracked order on the symbol. Exchanges without such symbols are omitted.
+func (t *Tracker) TrackedSymbols() map[ExchangeID][]SymbolID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	tracked := make(map[ExchangeID][]SymbolID)
+	for exchangeID, exchange := range t.exchanges {
+		for symbolID, symbolContext := range exchange {
+			if symbolContext.hasQuote || len(symbolContext.orders) != 0 {
+				tracked[exchangeID] = append(tracked[exchangeID], symbolID)
+			}
+		}
+		slices.Sort(tracked[exchangeID])
+	}
+	return tracked
 }
 
 // GetOrdersCount returns the number of tracked orders.
@@ -302,3 +1913,512 @@ func (t *Tracker) GetOrdersCount() int {
 	defer t.guard.Unlock()
 	return len(t.orders)
 }
+
+// GetActiveOrders returns the sorted client IDs of orders that may still be live on the exchange,
+// that is orders in OrderPlacing, OrderPlaced, OrderModifying, OrderCanceling or OrderUnknown state.
+// Orders in OrderUnplaced, OrderFilled and OrderExpired states are terminal and not returned.
+func (t *Tracker) GetActiveOrders() []OrderClientID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var active []OrderClientID
+	for clid, orderContext := range t.orders {
+		if orderContext.Status.isActive() {
+			active = append(active, clid)
+		}
+	}
+	slices.Sort(active)
+	return active
+}
+
+// GetOrdersByTag returns the sorted client IDs of tracked orders with the given tag in every status
+// including terminal ones.
+func (t *Tracker) GetOrdersByTag(tag string) []OrderClientID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var tagged []OrderClientID
+	for clid, orderContext := range t.orders {
+		if orderContext.Order.Tag == tag {
+			tagged = append(tagged, clid)
+		}
+	}
+	slices.Sort(tagged)
+	return tagged
+}
+
+// GetActiveOrdersCount returns the number of orders in the states GetActiveOrders considers active
+// without allocating.
+func (t *Tracker) GetActiveOrdersCount() int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	return t.activeOrdersCount()
+}
+
+// activeOrdersCount returns the number of orders in active states, the guard should be held.
+func (t *Tracker) activeOrdersCount() int {
+	count := 0
+	for _, orderContext := range t.orders {
+		if orderContext.Status.isActive() {
+			count++
+		}
+	}
+	return count
+}
+
+// GetOrdersCountByStatus returns the number of tracked orders per status.
+// Statuses without orders are omitted from the result.
+func (t *Tracker) GetOrdersCountByStatus() map[OrderStatus]int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	counts := make(map[OrderStatus]int)
+	for _, orderContext := range t.orders {
+		counts[orderContext.Status]++
+	}
+	return counts
+}
+
+// GetOrdersCountByExchange returns the number of tracked orders per exchange.
+// Like GetOrdersCount, it counts orders in every status including terminal ones.
+func (t *Tracker) GetOrdersCountByExchange() map[ExchangeID]int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	counts := make(map[ExchangeID]int)
+	for _, orderContext := range t.orders {
+		counts[orderContext.Order.Exchange]++
+	}
+	return counts
+}
+
+// GetOrdersCountBySymbol returns the number of tracked orders per symbol on the given exchange.
+// Like GetOrdersCount, it counts orders in every status including terminal ones.
+func (t *Tracker) GetOrdersCountBySymbol(exchange ExchangeID) map[SymbolID]int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	counts := make(map[SymbolID]int)
+	for _, orderContext := range t.orders {
+		if orderContext.Order.Exchange == exchange {
+			counts[orderContext.Order.Symbol]++
+		}
+	}
+	return counts
+}
+
+// GetOrdersCountWhere returns the number of tracked orders in every status including terminal ones
+// for which the predicate returns true, for example the orders not on a given exchange.
+// The predicate is called holding the guard, so it must not call the tracker.
+func (t *Tracker) GetOrdersCountWhere(pred func(order Order, status OrderStatus) bool) int {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	count := 0
+	for _, orderContext := range t.orders {
+		if pred(orderContext.Order, orderContext.Status) {
+			count++
+		}
+	}
+	return count
+}
+
+// TurnoverWindow returns the total filled notional (amount × price) of orders on the given symbol
+// across all exchanges, counting only fills with time within [now - window, now].
+// Only fills kept in the fill history are counted, so it requires WithFillHistory.
+// The result saturates at math.MaxUint64 instead of overflowing.
+func (t *Tracker) TurnoverWindow(symbol SymbolID, window time.Duration, now time.Time) uint64 {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	from := now.Add(-window)
+	var turnover uint128
+	for _, orderContext := range t.orders {
+		if orderContext.Order.Symbol != symbol {
+			continue
+		}
+		for _, fill := range orderContext.Fills {
+			if fill.Time.Before(from) || fill.Time.After(now) {
+				continue
+			}
+			turnover = turnover.add(mul64(fill.Amount, uint64(fill.Price)))
+		}
+	}
+	return turnover.saturated()
+}
+
+// FindStaleOrders returns the client IDs of orders that have been in the given status
+// for longer than olderThan as of now, sorted. It is intended for watchdogs detecting orders
+// stuck in transient statuses like OrderPlacing or OrderModifying due to a lost exchange message.
+func (t *Tracker) FindStaleOrders(status OrderStatus, olderThan time.Duration, now time.Time) []OrderClientID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var stale []OrderClientID
+	for clid, orderContext := range t.orders {
+		if orderContext.Status == status && now.Sub(orderContext.StatusSince) > olderThan {
+			stale = append(stale, clid)
+		}
+	}
+	slices.Sort(stale)
+	return stale
+}
+
+// StaleOrder is an order in a status along with the time it has been in the status.
+type StaleOrder struct {
+	ClientID OrderClientID
+	Age      time.Duration
+}
+
+// OrdersByStaleness returns the orders in the given status with the time they have been in it
+// as of now, oldest first. Orders of the same age are sorted by client ID.
+// It is intended for triage views of orders stuck in a status, see FindStaleOrders.
+func (t *Tracker) OrdersByStaleness(status OrderStatus, now time.Time) []StaleOrder {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var stale []StaleOrder
+	for clid, orderContext := range t.orders {
+		if orderContext.Status == status {
+			stale = append(stale, StaleOrder{ClientID: clid, Age: now.Sub(orderContext.StatusSince)})
+		}
+	}
+	slices.SortFunc(stale, func(a, b StaleOrder) int {
+		if c := cmp.Compare(b.Age, a.Age); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.ClientID), string(b.ClientID))
+	})
+	return stale
+}
+
+// FindDuplicateIntents groups active orders having identical exchange, symbol, side, amount and price
+// that were placed within [now - window, now]. Only groups of two or more orders are returned,
+// client IDs are sorted within each group and groups are sorted by their first client ID.
+// Such groups usually indicate a double submission of the same logical order.
+func (t *Tracker) FindDuplicateIntents(window time.Duration, now time.Time) [][]OrderClientID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	type intent struct {
+		exchange ExchangeID
+		symbol   SymbolID
+		side     OrderSide
+		amount   uint64
+		price    Price
+	}
+
+	from := now.Add(-window)
+	intents := make(map[intent][]OrderClientID)
+	for clid, orderContext := range t.orders {
+		if !orderContext.Status.isActive() {
+			continue
+		}
+		if orderContext.PlacingTime.Before(from) || orderContext.PlacingTime.After(now) {
+			continue
+		}
+		order := &orderContext.Order
+		key := intent{order.Exchange, order.Symbol, order.Side, order.Amount, order.Price}
+		intents[key] = append(intents[key], clid)
+	}
+
+	var duplicates [][]OrderClientID
+	for _, group := range intents {
+		if len(group) < 2 {
+			continue
+		}
+		slices.Sort(group)
+		duplicates = append(duplicates, group)
+	}
+	slices.SortFunc(duplicates, func(a, b []OrderClientID) int {
+		return strings.Compare(string(a[0]), string(b[0]))
+	})
+	return duplicates
+}
+
+// Reconcile compares the active orders on the exchange against the client IDs the exchange
+// reports as live. missingRemotely are local active orders the exchange does not know about,
+// possibly rejected silently, missingLocally are live exchange orders not active in the tracker.
+// Orders in OrderPlacing are considered active, so a placement in flight may show up
+// in missingRemotely. Both results are sorted.
+func (t *Tracker) Reconcile(exchange ExchangeID, liveIDs []OrderClientID) (missingLocally, missingRemotely []OrderClientID) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	live := make(map[OrderClientID]struct{}, len(liveIDs))
+	for _, clid := range liveIDs {
+		live[clid] = struct{}{}
+		orderContext := t.orders[clid]
+		if orderContext == nil || orderContext.Order.Exchange != exchange || !orderContext.Status.isActive() {
+			missingLocally = append(missingLocally, clid)
+		}
+	}
+	for clid, orderContext := range t.orders {
+		if orderContext.Order.Exchange != exchange || !orderContext.Status.isActive() {
+			continue
+		}
+		if _, exists := live[clid]; !exists {
+			missingRemotely = append(missingRemotely, clid)
+		}
+	}
+	slices.Sort(missingLocally)
+	slices.Sort(missingRemotely)
+	return missingLocally, missingRemotely
+}
+
+// OrdersAtPrice returns the sorted client IDs of active orders on the exchange and symbol
+// with the price within tolerance of the given price.
+func (t *Tracker) OrdersAtPrice(exchange ExchangeID, symbol SymbolID, price Price, tolerance uint64) []OrderClientID {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var found []OrderClientID
+	for clid, orderContext := range t.orders {
+		order := &orderContext.Order
+		if order.Exchange != exchange || order.Symbol != symbol || !orderContext.Status.isActive() {
+			continue
+		}
+		if order.Price.Distance(price) <= tolerance {
+			found = append(found, clid)
+		}
+	}
+	slices.Sort(found)
+	return found
+}
+
+// TimeToFirstFill returns the time the order rested between the placement confirmation
+// and its first fill. If the order was filled before the placement was confirmed,
+// the time is measured from OrderPlacing instead.
+// The boolean result is false if the order is not found or has not been filled yet.
+func (t *Tracker) TimeToFirstFill(clid OrderClientID) (time.Duration, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, false
+	}
+	firstFillTime, ok := orderContext.firstFillTime()
+	if !ok {
+		return 0, false
+	}
+	restingSince := orderContext.PlacedTime
+	if restingSince.IsZero() {
+		restingSince = orderContext.PlacingTime
+	}
+	return firstFillTime.Sub(restingSince), true
+}
+
+// NotionalShareByExchange returns the share of each exchange in the total resting notional,
+// where the resting notional of an active order is its remaining amount × price.
+// The shares sum up to 1. Returns an empty map if there is no resting notional.
+func (t *Tracker) NotionalShareByExchange() map[ExchangeID]float64 {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var total uint128
+	notionals := make(map[ExchangeID]uint128)
+	for _, orderContext := range t.orders {
+		if !orderContext.Status.isActive() {
+			continue
+		}
+		notional := mul64(orderContext.remaining(), uint64(orderContext.Order.Price))
+		notionals[orderContext.Order.Exchange] = notionals[orderContext.Order.Exchange].add(notional)
+		total = total.add(notional)
+	}
+
+	shares := make(map[ExchangeID]float64)
+	if total == (uint128{}) {
+		return shares
+	}
+	for exchange, notional := range notionals {
+		shares[exchange] = notional.float64() / total.float64()
+	}
+	return shares
+}
+
+// FilledNotional returns the cash value of the order fills (sum of amount × price).
+// The value saturates at math.MaxUint64 instead of overflowing and is zero for unfilled orders.
+// Returns an error if the order is not found.
+func (t *Tracker) FilledNotional(clid OrderClientID) (uint64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	_, value := orderContext.filled()
+	return value.saturated(), nil
+}
+
+// CumulativeFilledValue returns the exact cash value of the order fills (sum of amount × price)
+// as a decimal string, unlike FilledNotional it never saturates. It is "0" for unfilled orders.
+// Returns an error if the order is not found.
+func (t *Tracker) CumulativeFilledValue(clid OrderClientID) (string, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return "", fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	_, value := orderContext.filled()
+	return value.String(), nil
+}
+
+// DisplayedRemaining returns the amount of the order displayed on the exchange and not executed yet.
+// For an iceberg order it is the rest of the current slice, see Order.DisplayQuantity,
+// otherwise it is the remaining amount of the order.
+// Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) DisplayedRemaining(clid OrderClientID) (uint64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	return orderContext.displayed(), nil
+}
+
+// FillRatio returns the executed share of the order amount in [0, 1].
+// Fills exceeding the order amount are rejected with ErrOverfill, so the ratio never exceeds 1,
+// and orders with zero amount have the ratio of 0.
+// Returns ErrOrderNotFound if the order does not exist.
+func (t *Tracker) FillRatio(clid OrderClientID) (float64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Order.Amount == 0 {
+		return 0, nil
+	}
+	filledAmount, _ := orderContext.filled()
+	return min(float64(filledAmount)/float64(orderContext.Order.Amount), 1), nil
+}
+
+// AverageFillPrice returns the volume-weighted average price across all fills of the order,
+// regardless of the kind of the latest execution report.
+// The boolean result is false if the order is not found or has no fills.
+func (t *Tracker) AverageFillPrice(clid OrderClientID) (Price, bool) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, false
+	}
+	amount, value := orderContext.filled()
+	if amount == 0 {
+		return 0, false
+	}
+	return Price(value.div64(amount)), true
+}
+
+// PriceImprovement returns the total value (sum of amount × price difference) by which the order
+// fills were better than the order price at the time of each fill: below it for buy orders
+// and above it for sell orders. Fills at worse prices add nothing, they are measured by Slippage.
+// The value saturates at math.MaxUint64 instead of overflowing.
+// Returns an error if the order is not found, is a market order, has no side or has no fills yet.
+func (t *Tracker) PriceImprovement(clid OrderClientID) (uint64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	switch {
+	case orderContext.Order.Type == TypeMarket:
+		return 0, fmt.Errorf("market order has no limit price (clid %v)", clid)
+	case orderContext.Order.Side != SideBuy && orderContext.Order.Side != SideSell:
+		return 0, fmt.Errorf("order has no side (clid %v)", clid)
+	}
+	if filledAmount, _ := orderContext.filled(); filledAmount == 0 {
+		return 0, fmt.Errorf("order has no fills (clid %v)", clid)
+	}
+	return orderContext.Improvement.saturated(), nil
+}
+
+// Slippage returns the signed difference between the average fill price and the order price,
+// positive when adverse: a buy order filled above its price or a sell order filled below it.
+// Orders without side are measured like buy orders.
+// Returns an error if the order is not found, is a market order or has no fills yet.
+func (t *Tracker) Slippage(clid OrderClientID) (int64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+	return t.slippage(clid)
+}
+
+// slippage implements Slippage, the guard should be held.
+func (t *Tracker) slippage(clid OrderClientID) (int64, error) {
+	orderContext := t.orders[clid]
+	if orderContext == nil {
+		return 0, fmt.Errorf("%w (clid %v)", ErrOrderNotFound, clid)
+	}
+	if orderContext.Order.Type == TypeMarket {
+		return 0, fmt.Errorf("market order has no limit price (clid %v)", clid)
+	}
+	amount, value := orderContext.filled()
+	if amount == 0 {
+		return 0, fmt.Errorf("order has no fills (clid %v)", clid)
+	}
+	average, price := value.div64(amount), uint64(orderContext.Order.Price)
+	if orderContext.Order.Side == SideSell {
+		return signedDiff(price, average), nil
+	}
+	return signedDiff(average, price), nil
+}
+
+// SlippageTicks returns Slippage expressed in ticks of the symbol registered with RegisterSymbol,
+// truncated toward zero.
+// Returns an error if Slippage fails or the symbol has no tick size.
+func (t *Tracker) SlippageTicks(clid OrderClientID) (int64, error) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	slippage, err := t.slippage(clid)
+	if err != nil {
+		return 0, err
+	}
+	order := &t.orders[clid].Order
+	tickSize, err := t.tickSize(order.Exchange, order.Symbol)
+	if err != nil {
+		return 0, err
+	}
+	if tickSize > math.MaxInt64 {
+		return 0, nil
+	}
+	return slippage / int64(tickSize), nil
+}
+
+// Inventory returns the filled inventory of all orders on the exchange and symbol:
+// net is the bought amount minus the sold amount, gross is the total filled amount.
+// Fills of orders without side contribute to gross only.
+// Both values are clamped to their type range instead of overflowing.
+func (t *Tracker) Inventory(exchange ExchangeID, symbol SymbolID) (net int64, gross uint64) {
+	t.guard.Lock()
+	defer t.guard.Unlock()
+
+	var bought, sold, total uint128
+	for _, orderContext := range t.orders {
+		order := &orderContext.Order
+		if order.Exchange != exchange || order.Symbol != symbol {
+			continue
+		}
+		amount, _ := orderContext.filled()
+		filled := uint128{lo: amount}
+		switch order.Side {
+		case SideBuy:
+			bought = bought.add(filled)
+		case SideSell:
+			sold = sold.add(filled)
+		}
+		total = total.add(filled)
+	}
+	return signedDiff(bought.saturated(), sold.saturated()), total.saturated()
+}
@@ -1,6 +1,16 @@
 package orderstracker
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
 
 func TestTracker_OrderPlacing(t *testing.T) {
 	tracker := NewTracker()
@@ -23,6 +33,2998 @@ func TestTracker_OrderPlacing(t *testing.T) {
 	}
 }
 
+func TestTracker_GetOrdersCountByStatus(t *testing.T) {
+	tracker := NewTracker()
+	wantSymbol := SymbolID("TEST")
+	now := time.Now()
+	placing := GenerateOrderWithSymbol(wantSymbol)
+	placed := GenerateOrderWithSymbol(wantSymbol)
+	canceled := GenerateOrderWithSymbol(wantSymbol)
+	for _, order := range []Order{placing, placed, canceled} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for _, order := range []Order{placed, canceled} {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderCancelling(canceled.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(canceled.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	got := tracker.GetOrdersCountByStatus()
+	want := map[OrderStatus]int{OrderPlacing: 1, OrderPlaced: 1, OrderUnplaced: 1}
+	if len(got) != len(want) {
+		t.Errorf("Should contain only present statuses: %v", got)
+	}
+	for status, count := range want {
+		if got[status] != count {
+			t.Errorf("Unexpected count for '%s': %v != %v", status, got[status], count)
+		}
+	}
+}
+
+func TestTracker_GetOrdersCountByExchangeAndSymbol(t *testing.T) {
+	tracker := NewTracker()
+	orders := []Order{
+		NewOrder("1", ExchangeBinance, "BTC", 1, 1),
+		NewOrder("2", ExchangeBinance, "BTC", 1, 1),
+		NewOrder("3", ExchangeBinance, "ETH", 1, 1),
+		NewOrder("4", ExchangeKraken, "BTC", 4, 1),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("4", time.Now(), "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	byExchange := tracker.GetOrdersCountByExchange()
+	if byExchange[ExchangeBinance] != 3 || byExchange[ExchangeKraken] != 1 || len(byExchange) != 2 {
+		t.Errorf("Unexpected counts by exchange: %v", byExchange)
+	}
+	bySymbol := tracker.GetOrdersCountBySymbol(ExchangeBinance)
+	if bySymbol["BTC"] != 2 || bySymbol["ETH"] != 1 || len(bySymbol) != 2 {
+		t.Errorf("Unexpected counts by symbol: %v", bySymbol)
+	}
+	if bySymbol := tracker.GetOrdersCountBySymbol(ExchangeNone); len(bySymbol) != 0 {
+		t.Errorf("Should not count symbols of exchange without orders: %v", bySymbol)
+	}
+}
+
+func TestTracker_GetOrdersCountWhere(t *testing.T) {
+	tracker := NewTracker()
+	orders := []Order{
+		NewOrder("1", ExchangeBinance, "BTC", 1, 1),
+		NewOrder("2", ExchangeBinance, "ETH", 1, 1),
+		NewOrder("3", ExchangeKraken, "BTC", 1, 1),
+		NewOrder("4", ExchangeKraken, "BTC", 4, 1),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("4", time.Now(), "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	tests := []struct {
+		name string
+		pred func(Order, OrderStatus) bool
+		want int
+	}{
+		{"all", func(Order, OrderStatus) bool { return true }, 4},
+		{"none", func(Order, OrderStatus) bool { return false }, 0},
+		{"not on exchange", func(order Order, _ OrderStatus) bool { return order.Exchange != ExchangeKraken }, 2},
+		{"active symbol", func(order Order, status OrderStatus) bool {
+			return order.Symbol == "BTC" && status.isActive()
+		}, 2},
+	}
+	for _, test := range tests {
+		if got := tracker.GetOrdersCountWhere(test.pred); got != test.want {
+			t.Errorf("Unexpected count of %s orders: %d != %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTracker_TurnoverWindow(t *testing.T) {
+	tracker := NewTracker(WithFillHistory())
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	other := GenerateOrderWithSymbol("OTHER")
+	for _, o := range []Order{order, other} {
+		if e := tracker.OrderPlacing(o); e != nil {
+			t.Fatal(e)
+		}
+	}
+	fills := []struct {
+		clid   OrderClientID
+		time   time.Time
+		amount uint64
+		price  Price
+	}{
+		{order.ClientID, now.Add(-2 * time.Hour), 100, 10},
+		{order.ClientID, now.Add(-30 * time.Minute), 10, 20},
+		{order.ClientID, now.Add(-time.Minute), 5, 30},
+		{other.ClientID, now.Add(-time.Minute), 1000, 1000},
+	}
+	for _, fill := range fills {
+		if _, e := tracker.OrderFilled(fill.clid, fill.time, fill.amount, fill.price); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if got := tracker.TurnoverWindow("TEST", time.Hour, now); got != 10*20+5*30 {
+		t.Errorf("Unexpected turnover within an hour: %v", got)
+	}
+	if got := tracker.TurnoverWindow("TEST", 3*time.Hour, now); got != 100*10+10*20+5*30 {
+		t.Errorf("Unexpected turnover within three hours: %v", got)
+	}
+	if got := tracker.TurnoverWindow("NONE", time.Hour, now); got != 0 {
+		t.Errorf("Should not have turnover for unknown symbol: %v", got)
+	}
+}
+
+func TestTracker_TurnoverWindowSaturates(t *testing.T) {
+	tracker := NewTracker(WithFillHistory())
+	now := time.Now()
+	order := NewOrder("HUGE", ExchangeBinance, "TEST", math.MaxUint64/2, 4)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, math.MaxUint64/2, 4); e != nil {
+		t.Fatal(e)
+	}
+	if got := tracker.TurnoverWindow("TEST", time.Hour, now); got != math.MaxUint64 {
+		t.Errorf("Should saturate on overflow: %v", got)
+	}
+}
+
+func TestTracker_Halt(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	placed := GenerateOrderWithSymbol("TEST")
+	placing := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(placed); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(placed.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(placing); e != nil {
+		t.Fatal(e)
+	}
+
+	tracker.Halt("risk limit")
+	if halted, reason := tracker.IsHalted(); !halted || reason != "risk limit" {
+		t.Errorf("Should be halted with reason: %v, '%s'", halted, reason)
+	}
+	mutations := map[string]func() error{
+		"OrderPlacing":         func() error { return tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")) },
+		"OrderPlaceConfirmed":  func() error { _, e := tracker.OrderPlaceConfirmed(placing.ClientID, now); return e },
+		"OrderRejected":        func() error { _, e := tracker.OrderRejected(placing.ClientID, now, "reject"); return e },
+		"OrderMoving":          func() error { return tracker.OrderMoving(placed.ClientID) },
+		"OrderMoveConfirmed":   func() error { _, e := tracker.OrderMoveConfirmed(placed.ClientID, now, 1); return e },
+		"OrderCancelling":      func() error { return tracker.OrderCancelling(placed.ClientID) },
+		"OrderCancelConfirmed": func() error { _, e := tracker.OrderCancelConfirmed(placed.ClientID, now); return e },
+		"OrderFilled":          func() error { _, e := tracker.OrderFilled(placed.ClientID, now, 1, 1); return e },
+	}
+	for name, mutation := range mutations {
+		if e := mutation(); !errors.Is(e, ErrHalted) {
+			t.Errorf("%s should be rejected while halted: %v", name, e)
+		}
+	}
+	var gotOrder Order
+	var gotReport ExecutionReport
+	gotStatus, e := tracker.GetOrderStatus(placed.ClientID, &gotOrder, &gotReport)
+	if e != nil {
+		t.Error(e)
+	}
+	if gotStatus != OrderPlaced {
+		t.Errorf("Order status should stay unchanged while halted: %s", gotStatus)
+	}
+	if tracker.GetOrdersCount() != 2 {
+		t.Error("Should not place orders while halted")
+	}
+
+	tracker.Unhalt()
+	if halted, _ := tracker.IsHalted(); halted {
+		t.Error("Should not be halted after unhalt")
+	}
+	if e := tracker.OrderCancelling(placed.ClientID); e != nil {
+		t.Error(e)
+	}
+}
+
+func TestTracker_FindStaleOrders(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return start }
+	stuck := NewOrder("STUCK_B", ExchangeBinance, "TEST", 1, 100)
+	anotherStuck := NewOrder("STUCK_A", ExchangeBinance, "TEST", 1, 100)
+	confirmed := NewOrder("CONFIRMED", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{stuck, anotherStuck, confirmed} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed(confirmed.ClientID, start.Add(time.Minute)); e != nil {
+		t.Fatal(e)
+	}
+	now := start.Add(90 * time.Second)
+
+	got := tracker.FindStaleOrders(OrderPlacing, time.Minute, now)
+	if want := []OrderClientID{anotherStuck.ClientID, stuck.ClientID}; !slices.Equal(got, want) {
+		t.Errorf("Should find sorted orders stuck in placing: %v != %v", got, want)
+	}
+	if got := tracker.FindStaleOrders(OrderPlaced, time.Minute, now); len(got) != 0 {
+		t.Errorf("Should measure age from the last transition: %v", got)
+	}
+	if got := tracker.FindStaleOrders(OrderPlaced, 10*time.Second, now); len(got) != 1 || got[0] != confirmed.ClientID {
+		t.Errorf("Should find order placed long enough: %v", got)
+	}
+}
+
+func TestTracker_OrdersByStaleness(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	now := start
+	tracker.now = func() time.Time { return now }
+	for i, clid := range []OrderClientID{"MIDDLE", "OLDEST", "NEWEST", "SAME_AS_MIDDLE", "PLACED"} {
+		now = start.Add(time.Duration([]int{20, 0, 50, 20, 0}[i]) * time.Second)
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 100)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed("PLACED", start.Add(30*time.Second)); e != nil {
+		t.Fatal(e)
+	}
+
+	got := tracker.OrdersByStaleness(OrderPlacing, start.Add(time.Minute))
+	want := []StaleOrder{
+		{ClientID: "OLDEST", Age: time.Minute},
+		{ClientID: "MIDDLE", Age: 40 * time.Second},
+		{ClientID: "SAME_AS_MIDDLE", Age: 40 * time.Second},
+		{ClientID: "NEWEST", Age: 10 * time.Second},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Unexpected stale orders: %+v != %+v", got, want)
+	}
+	if got := tracker.OrdersByStaleness(OrderPlaced, start.Add(time.Minute)); !slices.Equal(got,
+		[]StaleOrder{{ClientID: "PLACED", Age: 30 * time.Second}}) {
+		t.Errorf("Should measure age from the last transition: %+v", got)
+	}
+	if got := tracker.OrdersByStaleness(OrderCanceling, start); got != nil {
+		t.Errorf("Should find no orders in status: %+v", got)
+	}
+}
+
+func TestTracker_GetOrderStatusString(t *testing.T) {
+	tracker := NewTracker()
+	if e := tracker.OrderPlacing(NewOrder("ORDER", ExchangeBinance, "TEST", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	if status, e := tracker.GetOrderStatusString("ORDER"); e != nil || status != "Placing" {
+		t.Errorf("Unexpected status: %q, %v", status, e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed("ORDER", time.Now()); e != nil {
+		t.Fatal(e)
+	}
+	if status, e := tracker.GetOrderStatusString("ORDER"); e != nil || status != "Placed" {
+		t.Errorf("Unexpected status: %q, %v", status, e)
+	}
+	if status, e := tracker.GetOrderStatusString("unknown"); !errors.Is(e, ErrOrderNotFound) || status != "" {
+		t.Errorf("Should not find order: %q, %v", status, e)
+	}
+}
+
+func TestTracker_GetOrderStatusNilOutParameters(t *testing.T) {
+	tracker := NewTracker()
+	order := NewOrder("NIL", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	var gotOrder Order
+	var gotReport ExecutionReport
+	tests := []struct {
+		order  *Order
+		report *ExecutionReport
+	}{
+		{nil, nil},
+		{&gotOrder, nil},
+		{nil, &gotReport},
+		{&gotOrder, &gotReport},
+	}
+	for _, test := range tests {
+		gotOrder, gotReport = Order{}, ExecutionReport{Kind: ReportFilled}
+		status, e := tracker.GetOrderStatus(order.ClientID, test.order, test.report)
+		if e != nil || status != OrderPlacing {
+			t.Errorf("Unexpected status: %s, %v", status, e)
+		}
+		if (test.order != nil) != (gotOrder == order) {
+			t.Errorf("Should copy order only if requested: %+v", gotOrder)
+		}
+		if (test.report != nil) != (gotReport.Kind == ReportNone) {
+			t.Errorf("Should copy report only if requested: %+v", gotReport)
+		}
+	}
+	if _, e := tracker.GetOrderStatus("unknown", nil, nil); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+}
+
+func TestTracker_OrderPlaced(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return start }
+	var changes []OrderStatus
+	tracker.OnStatusChange(func(_ OrderClientID, from, to OrderStatus) {
+		changes = append(changes, from, to)
+	})
+	order := NewOrder("SYNC", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlaced(order, start.Add(time.Millisecond)); e != nil {
+		t.Fatal(e)
+	}
+	if want := []OrderStatus{OrderUnplaced, OrderPlacing, OrderPlacing, OrderPlaced}; !slices.Equal(changes, want) {
+		t.Errorf("Should notify of both status changes: %v != %v", changes, want)
+	}
+	status, gotOrder, report, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderPlaced || gotOrder != order {
+		t.Errorf("Unexpected placed order: %s, %+v", status, gotOrder)
+	}
+	if report.Kind != ReportPlaced || !report.Time.Equal(start.Add(time.Millisecond)) {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if e := tracker.OrderPlaced(order, start); e == nil {
+		t.Error("Should not place order twice")
+	}
+	if stats := tracker.Stats(); stats.Placing != 1 || stats.Placed != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Errorf("Should move synchronously placed order: %v", e)
+	}
+}
+
+func TestTracker_OrderPlacedAckJitter(t *testing.T) {
+	tracker := NewTracker(WithEventRing(16))
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return start }
+	acked := NewOrder("ACKED", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlacing(acked); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(acked.ClientID, start.Add(2*time.Second)); e != nil {
+		t.Fatal(e)
+	}
+	for i, offset := range []time.Duration{time.Second, -time.Second} {
+		order := NewOrder(OrderClientID(fmt.Sprint("SYNC", i)), ExchangeBinance, "TEST", 1, 100)
+		if e := tracker.OrderPlaced(order, start.Add(offset)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if mean, stddev := tracker.AckJitter(ExchangeBinance); mean != 2*time.Second || stddev != 0 {
+		t.Errorf("Should not sample synchronous placements: %v, %v", mean, stddev)
+	}
+
+	replayed, e := ReplayEvents(tracker.Events())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if mean, stddev := replayed.AckJitter(ExchangeBinance); mean != 2*time.Second || stddev != 0 {
+		t.Errorf("Should not sample replayed synchronous placements: %v, %v", mean, stddev)
+	}
+	if status, _, _, _ := replayed.GetCurrentStatus("SYNC0"); status != OrderPlaced {
+		t.Errorf("Should replay synchronous placement: %s", status)
+	}
+}
+
+func TestTracker_CanPlaceMoveCancel(t *testing.T) {
+	tracker := NewTracker(WithValidation(), WithMaxOrders(3))
+	now := time.Now()
+	placing := NewOrder("PLACING", ExchangeBinance, "TEST", 1, 100)
+	placed := NewOrder("PLACED", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlacing(placing); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(placed); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(placed.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	snapshot := tracker.GetSnapshot()
+
+	for _, order := range []Order{placing, NewOrder("", ExchangeBinance, "TEST", 1, 100), NewOrder("NEW", ExchangeBinance, "TEST", 1, 100)} {
+		if e := tracker.CanPlace(order); (e == nil) != (tracker.Clone().OrderPlacing(order) == nil) {
+			t.Errorf("CanPlace should match OrderPlacing for %v: %v", order.ClientID, e)
+		}
+	}
+	if e := tracker.CanPlace(placing); e == nil {
+		t.Error("Should not allow placing order twice")
+	}
+	for _, clid := range []OrderClientID{placing.ClientID, placed.ClientID, "unknown"} {
+		if e := tracker.CanMove(clid); (e == nil) != (tracker.Clone().OrderMoving(clid) == nil) {
+			t.Errorf("CanMove should match OrderMoving for %v: %v", clid, e)
+		}
+		if e := tracker.CanCancel(clid); (e == nil) != (tracker.Clone().OrderCancelling(clid) == nil) {
+			t.Errorf("CanCancel should match OrderCancelling for %v: %v", clid, e)
+		}
+	}
+	if e := tracker.CanMove(placed.ClientID); e != nil {
+		t.Errorf("Should allow moving placed order: %v", e)
+	}
+	if e := tracker.CanCancel("unknown"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+	if got := tracker.GetSnapshot(); len(got) != len(snapshot) || got[0].Status != snapshot[0].Status ||
+		got[1].Status != snapshot[1].Status || len(got[1].History) != len(snapshot[1].History) {
+		t.Errorf("Should not change tracked orders: %+v", got)
+	}
+}
+
+func TestTracker_CancelAll(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	placing := GenerateOrderWithSymbol("TEST")
+	placed := GenerateOrderWithSymbol("TEST")
+	anotherPlaced := GenerateOrderWithSymbol("TEST")
+	for _, order := range []Order{placing, placed, anotherPlaced} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for _, order := range []Order{placed, anotherPlaced} {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	got := tracker.CancelAll()
+	want := []OrderClientID{placed.ClientID, anotherPlaced.ClientID}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("Should cancel only placed orders in client ID order: %v != %v", got, want)
+	}
+	counts := tracker.GetOrdersCountByStatus()
+	if counts[OrderCanceling] != 2 || counts[OrderPlacing] != 1 {
+		t.Errorf("Unexpected statuses after cancel all: %v", counts)
+	}
+	if got := tracker.CancelAll(); len(got) != 0 {
+		t.Errorf("Should not cancel orders twice: %v", got)
+	}
+}
+
+func TestTracker_CancelSymbol(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	placing := NewOrder("PLACING", ExchangeBinance, "TEST", 1, 100)
+	placed := NewOrder("PLACED", ExchangeBinance, "TEST", 1, 100)
+	anotherSymbol := NewOrder("ANOTHER_SYMBOL", ExchangeBinance, "OTHER", 1, 100)
+	anotherExchange := NewOrder("ANOTHER_EXCHANGE", ExchangeKraken, "TEST", 1, 100)
+	for _, order := range []Order{placing, placed, anotherSymbol, anotherExchange} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for _, order := range []Order{placed, anotherSymbol, anotherExchange} {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	got := tracker.CancelSymbol(ExchangeBinance, "TEST")
+	if len(got) != 1 || got[0] != placed.ClientID {
+		t.Fatalf("Should cancel only placed orders on the symbol: %v", got)
+	}
+	counts := tracker.GetOrdersCountByStatus()
+	if counts[OrderCanceling] != 1 || counts[OrderPlaced] != 2 || counts[OrderPlacing] != 1 {
+		t.Errorf("Unexpected statuses after cancel symbol: %v", counts)
+	}
+	if got := tracker.CancelSymbol(ExchangeBinance, "TEST"); len(got) != 0 {
+		t.Errorf("Should not cancel orders twice: %v", got)
+	}
+	if got := tracker.CancelSymbol(ExchangeBinance, "UNKNOWN"); len(got) != 0 {
+		t.Errorf("Should cancel nothing on unknown symbol: %v", got)
+	}
+}
+
+func TestTracker_CancelModifyingOrders(t *testing.T) {
+	now := time.Now()
+	cancels := map[string]func(*Tracker) []OrderClientID{
+		"CancelAll":    (*Tracker).CancelAll,
+		"CancelSymbol": func(tracker *Tracker) []OrderClientID { return tracker.CancelSymbol(ExchangeBinance, "TEST") },
+		"CancelByTag":  func(tracker *Tracker) []OrderClientID { return tracker.CancelByTag("mm") },
+	}
+	for name, cancel := range cancels {
+		tracker := NewTracker()
+		order := NewOrder("MODIFYING", ExchangeBinance, "TEST", 1, 100).WithTag("mm")
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+		if e := tracker.OrderMoving(order.ClientID); e != nil {
+			t.Fatal(e)
+		}
+		if got := cancel(tracker); !slices.Equal(got, []OrderClientID{order.ClientID}) {
+			t.Errorf("%s should cancel modifying order: %v", name, got)
+			continue
+		}
+		if e := tracker.RejectCancel(order.ClientID, now, "too late"); e != nil {
+			t.Fatal(e)
+		}
+		if status := tracker.GetOrderStatuses([]OrderClientID{order.ClientID})[order.ClientID]; status != OrderModifying {
+			t.Errorf("%s should keep the pending modification: %s", name, status)
+		}
+	}
+}
+
+func TestTracker_OrdersByTag(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	placing := NewOrder("PLACING", ExchangeBinance, "TEST", 1, 100).WithTag("mm")
+	placed := NewOrder("PLACED", ExchangeBinance, "TEST", 1, 100).WithTag("mm")
+	anotherTag := NewOrder("ANOTHER_TAG", ExchangeKraken, "OTHER", 1, 100).WithTag("arb")
+	untagged := NewOrder("UNTAGGED", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{placing, placed, anotherTag, untagged} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for _, order := range []Order{placed, anotherTag, untagged} {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if got, want := tracker.GetOrdersByTag("mm"), []OrderClientID{"PLACED", "PLACING"}; !slices.Equal(got, want) {
+		t.Errorf("Unexpected tagged orders: %v != %v", got, want)
+	}
+	if got := tracker.GetOrdersByTag(""); !slices.Equal(got, []OrderClientID{"UNTAGGED"}) {
+		t.Errorf("Unexpected untagged orders: %v", got)
+	}
+	if snapshot := tracker.GetSnapshot(); snapshot[0].Order.Tag != "arb" {
+		t.Errorf("Should include tag in snapshot: %+v", snapshot[0].Order)
+	}
+
+	got := tracker.CancelByTag("mm")
+	if len(got) != 1 || got[0] != placed.ClientID {
+		t.Fatalf("Should cancel only placed orders with the tag: %v", got)
+	}
+	counts := tracker.GetOrdersCountByStatus()
+	if counts[OrderCanceling] != 1 || counts[OrderPlaced] != 2 || counts[OrderPlacing] != 1 {
+		t.Errorf("Unexpected statuses after cancel by tag: %v", counts)
+	}
+	if got := tracker.CancelByTag("mm"); len(got) != 0 {
+		t.Errorf("Should not cancel orders twice: %v", got)
+	}
+	if got := tracker.CancelByTag("unknown"); len(got) != 0 {
+		t.Errorf("Should cancel nothing with unknown tag: %v", got)
+	}
+	if got := tracker.GetOrdersByTag("unknown"); got != nil {
+		t.Errorf("Should find nothing with unknown tag: %v", got)
+	}
+}
+
+func TestTracker_FindDuplicateIntents(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	placingTime := start
+	tracker.now = func() time.Time { return placingTime }
+	orders := []Order{
+		NewOrder("a1", ExchangeBinance, "BTC", 10, 100),
+		NewOrder("a2", ExchangeBinance, "BTC", 10, 100),
+		NewOrder("b1", ExchangeBinance, "BTC", 10, 101),
+		NewOrder("c1", ExchangeKraken, "BTC", 10, 100),
+		NewOrder("d1", ExchangeBinance, "ETH", 5, 50),
+		NewOrder("d2", ExchangeBinance, "ETH", 5, 50),
+		NewOrder("d3", ExchangeBinance, "ETH", 5, 50),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	placingTime = start.Add(time.Hour)
+	if e := tracker.OrderPlacing(NewOrder("a3", ExchangeBinance, "BTC", 10, 100)); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected("d3", start, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	got := tracker.FindDuplicateIntents(time.Minute, start.Add(30*time.Second))
+	want := [][]OrderClientID{{"a1", "a2"}, {"d1", "d2"}}
+	if len(got) != len(want) {
+		t.Fatalf("Unexpected duplicate groups: %v", got)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("Unexpected duplicate group: %v != %v", got[i], want[i])
+		}
+	}
+	if got := tracker.FindDuplicateIntents(time.Minute, start.Add(2*time.Hour)); len(got) != 0 {
+		t.Errorf("Should not group orders placed outside the window: %v", got)
+	}
+}
+
+func TestTracker_FindDuplicateIntentsOppositeSides(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	sides := map[OrderClientID]OrderSide{"B": SideBuy, "S1": SideSell, "S2": SideSell}
+	for clid, side := range sides {
+		order := NewOrder(clid, ExchangeBinance, "BTC", 10, 100)
+		order.Side = side
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	got := tracker.FindDuplicateIntents(time.Minute, now)
+	if len(got) != 1 || !slices.Equal(got[0], []OrderClientID{"S1", "S2"}) {
+		t.Errorf("Should not group orders of opposite sides: %v", got)
+	}
+}
+
+func TestTracker_PushQuoteValidation(t *testing.T) {
+	tracker := NewTracker(WithValidation())
+	if _, e := tracker.PushQuote(ExchangeNone, "TEST", 1, 2); !errors.Is(e, ErrInvalidQuote) {
+		t.Errorf("Should reject quote without exchange: %v", e)
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "", 1, 2); !errors.Is(e, ErrInvalidQuote) {
+		t.Errorf("Should reject quote without symbol: %v", e)
+	}
+	if len(tracker.exchanges) != 0 {
+		t.Error("Should not create market data for rejected quotes")
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "TEST", 1, 2); e != nil {
+		t.Errorf("Should accept valid quote: %v", e)
+	}
+
+	if _, e := NewTracker().PushQuote(ExchangeNone, "", 1, 2); e != nil {
+		t.Errorf("Should accept any quote without validation: %v", e)
+	}
+}
+
+func TestTracker_TimeToFirstFill(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return start }
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start.Add(time.Second)); e != nil {
+		t.Fatal(e)
+	}
+	if _, filled := tracker.TimeToFirstFill(order.ClientID); filled {
+		t.Error("Should not report time to fill before a fill")
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, start.Add(5*time.Second), 1, 1); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, start.Add(9*time.Second), 1, 1); e != nil {
+		t.Fatal(e)
+	}
+	got, filled := tracker.TimeToFirstFill(order.ClientID)
+	if !filled {
+		t.Fatal("Should report time to fill after a fill")
+	}
+	if got != 4*time.Second {
+		t.Errorf("Unexpected time to first fill: %v", got)
+	}
+	if _, filled := tracker.TimeToFirstFill("unknown"); filled {
+		t.Error("Should not report time to fill for unknown order")
+	}
+}
+
+func TestTracker_WithFillHistory(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	for _, history := range []bool{false, true} {
+		var opts []Option
+		if history {
+			opts = append(opts, WithFillHistory())
+		}
+		tracker := NewTracker(opts...)
+		order := NewOrder("HIST", ExchangeBinance, "TEST", 10, 100)
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		for i := range 3 {
+			if _, e := tracker.OrderFilled(order.ClientID, now.Add(time.Duration(i)*time.Second), 2, 100); e != nil {
+				t.Fatal(e)
+			}
+		}
+		snapshot := tracker.GetSnapshot()[0]
+		if history && len(snapshot.Fills) != 3 || !history && len(snapshot.Fills) != 0 {
+			t.Errorf("Unexpected fill history with WithFillHistory %v: %+v", history, snapshot.Fills)
+		}
+		if report, _ := tracker.GetExecutionReport(order.ClientID); report.Amount != 6 {
+			t.Errorf("Should aggregate fills regardless of history: %+v", report)
+		}
+		if got, filled := tracker.TimeToFirstFill(order.ClientID); !filled || got != now.Sub(snapshot.PlacingTime) {
+			t.Errorf("Should report time to first fill regardless of history: %v, %v", got, filled)
+		}
+	}
+}
+
+func TestTracker_NotionalShareByExchange(t *testing.T) {
+	tracker := NewTracker()
+	if got := tracker.NotionalShareByExchange(); len(got) != 0 {
+		t.Errorf("Should be empty without orders: %v", got)
+	}
+	orders := []Order{
+		NewOrder("1", ExchangeBinance, "BTC", 10, 30),
+		NewOrder("2", ExchangeBinance, "ETH", 16, 6),
+		NewOrder("3", ExchangeKraken, "BTC", math.MaxUint64, math.MaxUint64),
+		NewOrder("4", ExchangeKraken, "BTC", 4, 1),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("3", time.Now(), "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	got := tracker.NotionalShareByExchange()
+	if len(got) != 2 {
+		t.Fatalf("Unexpected shares: %v", got)
+	}
+	if math.Abs(got[ExchangeBinance]-0.99) > 1e-9 || math.Abs(got[ExchangeKraken]-0.01) > 1e-9 {
+		t.Errorf("Unexpected shares: %v", got)
+	}
+	if sum := got[ExchangeBinance] + got[ExchangeKraken]; math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Shares should sum to 1: %v", sum)
+	}
+}
+
+func TestTracker_FilledNotional(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := NewOrder("1", ExchangeBinance, "TEST", math.MaxUint64, 10)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.FilledNotional(order.ClientID); e != nil || got != 0 {
+		t.Errorf("Should be zero before fills: %v, %v", got, e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 10, 5); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 20, 7); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.FilledNotional(order.ClientID); e != nil || got != 10*5+20*7 {
+		t.Errorf("Unexpected filled notional: %v, %v", got, e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, math.MaxUint64/2, 10); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.FilledNotional(order.ClientID); e != nil || got != math.MaxUint64 {
+		t.Errorf("Should saturate on overflow: %v, %v", got, e)
+	}
+	if _, e := tracker.FilledNotional("unknown"); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+}
+
+func TestTracker_OrderCancelConfirmedWithReason(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmedWithReason(order.ClientID, now, "risk kill"); e != nil {
+		t.Fatal(e)
+	}
+	var gotOrder Order
+	var gotReport ExecutionReport
+	gotStatus, e := tracker.GetOrderStatus(order.ClientID, &gotOrder, &gotReport)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if gotStatus != OrderUnplaced || gotReport.Kind != ReportCanceled {
+		t.Errorf("Order should be canceled: %s, %v", gotStatus, gotReport.Kind)
+	}
+	if gotReport.Message != "risk kill" {
+		t.Errorf("Report should contain cancel reason: '%s'", gotReport.Message)
+	}
+}
+
+func TestTracker_DuplicateAcks(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+
+	assertUnchanged := func(ack string, wantStatus OrderStatus, wantReport ExecutionReport, wantHistory int) {
+		t.Helper()
+		var gotOrder Order
+		var gotReport ExecutionReport
+		gotStatus, e := tracker.GetOrderStatus(order.ClientID, &gotOrder, &gotReport)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if gotStatus != wantStatus || gotReport != wantReport {
+			t.Errorf("Duplicate %s should not change order: %s, %+v", ack, gotStatus, gotReport)
+		}
+		if history, _ := tracker.GetOrderHistory(order.ClientID); len(history) != wantHistory {
+			t.Errorf("Duplicate %s should not be recorded: %v", ack, history)
+		}
+	}
+
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start.Add(time.Second)); e != nil {
+		t.Errorf("Duplicate place ack should succeed: %v", e)
+	}
+	assertUnchanged("place ack", OrderPlaced, ExecutionReport{Kind: ReportPlaced, Time: start}, 2)
+
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, start.Add(2*time.Second), 42); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, start.Add(2*time.Second), 42); e != nil {
+		t.Errorf("Duplicate move ack should succeed: %v", e)
+	}
+	assertUnchanged("move ack", OrderPlaced, ExecutionReport{Kind: ReportModified, Time: start.Add(2 * time.Second), Price: 42}, 4)
+
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(order.ClientID, start.Add(3*time.Second)); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(order.ClientID, start.Add(3*time.Second)); e != nil {
+		t.Errorf("Duplicate cancel ack should succeed: %v", e)
+	}
+	assertUnchanged("cancel ack", OrderUnplaced, ExecutionReport{Kind: ReportCanceled, Time: start.Add(3 * time.Second), Price: 42}, 6)
+	if _, e := tracker.OrderCancelConfirmed(order.ClientID, start); e == nil {
+		t.Error("Stale cancel ack should not be treated as duplicate")
+	}
+}
+
+func TestTracker_OrderFilledWithTradeID(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	fills := []struct {
+		tradeID     string
+		wantApplied bool
+	}{
+		{"t1", true},
+		{"t2", true},
+		{"t1", false},
+		{"t2", false},
+		{"", true},
+		{"", true},
+	}
+	for _, fill := range fills {
+		applied, e := tracker.OrderFilledWithTradeID(order.ClientID, now, fill.tradeID, 10, 100)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if applied != fill.wantApplied {
+			t.Errorf("Unexpected applied flag for trade '%s': %v", fill.tradeID, applied)
+		}
+	}
+	var gotOrder Order
+	var report ExecutionReport
+	if _, e := tracker.GetOrderStatus(order.ClientID, &gotOrder, &report); e != nil {
+		t.Fatal(e)
+	}
+	if report.Amount != 40 {
+		t.Errorf("Duplicate fills should not be counted, fills without trade ID should: %v", report.Amount)
+	}
+	if _, e := tracker.OrderFilledWithTradeID("unknown", now, "t1", 1, 1); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+}
+
+func TestTracker_ExpireOrders(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	gtc := NewOrder("gtc", ExchangeBinance, "TEST", 1, 1)
+	gtd := NewOrder("gtd", ExchangeBinance, "TEST", 1, 1)
+	gtd.TimeInForce = TifGTD
+	gtd.ExpiresAt = start.Add(time.Hour)
+	gtdLater := gtd
+	gtdLater.ClientID = "gtd-later"
+	gtdLater.ExpiresAt = start.Add(2 * time.Hour)
+	ioc := NewOrder("ioc", ExchangeBinance, "TEST", 1, 1)
+	ioc.TimeInForce = TifIOC
+	iocFilled := ioc
+	iocFilled.ClientID = "ioc-filled"
+	for _, order := range []Order{gtc, gtd, gtdLater, ioc, iocFilled} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderFilled(iocFilled.ClientID, start, 1, 1); e != nil {
+		t.Fatal(e)
+	}
+
+	got := tracker.ExpireOrders(start.Add(time.Hour))
+	slices.Sort(got)
+	if !slices.Equal(got, []OrderClientID{"gtd", "ioc"}) {
+		t.Errorf("Unexpected expired orders: %v", got)
+	}
+	var gotOrder Order
+	var gotReport ExecutionReport
+	gotStatus, e := tracker.GetOrderStatus(gtd.ClientID, &gotOrder, &gotReport)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if gotStatus != OrderExpired || gotReport.Kind != ReportExpired {
+		t.Errorf("Expired order should be expired with expiry report: %s, %+v", gotStatus, gotReport)
+	}
+	if got := tracker.ExpireOrders(start.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("Should not expire orders twice: %v", got)
+	}
+	if got := tracker.ExpireOrders(start.Add(3 * time.Hour)); !slices.Equal(got, []OrderClientID{"gtd-later"}) {
+		t.Errorf("Unexpected expired orders: %v", got)
+	}
+}
+
+func TestTracker_OrderFilledComplete(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker()
+	order := NewOrder("PARTIAL", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	fills := []struct {
+		amount   uint64
+		complete bool
+		status   OrderStatus
+	}{
+		{4, false, OrderPlaced},
+		{5, false, OrderPlaced},
+		{1, true, OrderFilled},
+	}
+	for _, fill := range fills {
+		complete, e := tracker.OrderFilled(order.ClientID, now, fill.amount, 100)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if complete != fill.complete {
+			t.Errorf("Unexpected completion after fill of %d: %v", fill.amount, complete)
+		}
+		var gotOrder Order
+		var gotReport ExecutionReport
+		gotStatus, e := tracker.GetOrderStatus(order.ClientID, &gotOrder, &gotReport)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if gotStatus != fill.status {
+			t.Errorf("Unexpected status after fill of %d: %s != %s", fill.amount, gotStatus, fill.status)
+		}
+		if gotReport.Kind != ReportFilled {
+			t.Errorf("Should report fill: %s", gotReport.Kind)
+		}
+	}
+	if complete, e := tracker.OrderFilled("unknown", now, 1, 100); e == nil || complete {
+		t.Error("Should fail to fill unknown order")
+	}
+}
+
+func TestTracker_OrderFilledWhileInFlight(t *testing.T) {
+	now := time.Now()
+	newPlacedOrder := func(tracker *Tracker) Order {
+		t.Helper()
+		order := NewOrder(GenerateClientOrderID(), ExchangeBinance, "TEST", 10, 100)
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+		return order
+	}
+	status := func(tracker *Tracker, clid OrderClientID) OrderStatus {
+		t.Helper()
+		var gotOrder Order
+		var gotReport ExecutionReport
+		gotStatus, e := tracker.GetOrderStatus(clid, &gotOrder, &gotReport)
+		if e != nil {
+			t.Fatal(e)
+		}
+		return gotStatus
+	}
+
+	t.Run("fill during cancel", func(t *testing.T) {
+		tracker := NewTracker()
+		order := newPlacedOrder(tracker)
+		if e := tracker.OrderCancelling(order.ClientID); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, 4, 100); e != nil {
+			t.Fatal(e)
+		}
+		if got := status(tracker, order.ClientID); got != OrderCanceling {
+			t.Errorf("Partial fill should keep pending cancel: %s", got)
+		}
+		if _, e := tracker.OrderCancelConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+		if got := status(tracker, order.ClientID); got != OrderUnplaced {
+			t.Errorf("Order should be canceled: %s", got)
+		}
+		if got, _ := tracker.FilledNotional(order.ClientID); got != 400 {
+			t.Errorf("Fill should be kept after cancel: %v", got)
+		}
+	})
+
+	t.Run("fill during modify", func(t *testing.T) {
+		tracker := NewTracker()
+		order := newPlacedOrder(tracker)
+		if e := tracker.OrderMoving(order.ClientID); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, 4, 100); e != nil {
+			t.Fatal(e)
+		}
+		if got := status(tracker, order.ClientID); got != OrderModifying {
+			t.Errorf("Partial fill should keep pending modify: %s", got)
+		}
+		if _, e := tracker.OrderMoveConfirmed(order.ClientID, now, 101); e != nil {
+			t.Fatal(e)
+		}
+		if got := status(tracker, order.ClientID); got != OrderPlaced {
+			t.Errorf("Order should be placed after modify: %s", got)
+		}
+	})
+
+	t.Run("complete fill during cancel", func(t *testing.T) {
+		tracker := NewTracker()
+		order := newPlacedOrder(tracker)
+		if e := tracker.OrderCancelling(order.ClientID); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, 10, 100); e != nil {
+			t.Fatal(e)
+		}
+		if got := status(tracker, order.ClientID); got != OrderFilled {
+			t.Errorf("Complete fill should finish the order: %s", got)
+		}
+	})
+}
+
+func TestTracker_OrderCancellingWhileModifying(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := NewOrder("MODIFYING", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatalf("Should cancel modifying order: %v", e)
+	}
+	from, e := tracker.OrderMoveConfirmed(order.ClientID, now, 101)
+	if e != nil {
+		t.Fatalf("Should accept modification pending before cancel: %v", e)
+	}
+	if from != OrderCanceling {
+		t.Errorf("Unexpected status before move confirmation: %s", from)
+	}
+	status, gotOrder, _, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderCanceling {
+		t.Errorf("Should keep pending cancel after move confirmation: %s", status)
+	}
+	if gotOrder.Price != 101 {
+		t.Errorf("Should keep modified price: %v", gotOrder.Price)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, now, 102); e == nil {
+		t.Error("Should reject second move confirmation")
+	}
+	if _, e := tracker.OrderCancelConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if status := tracker.GetOrderStatuses([]OrderClientID{order.ClientID})[order.ClientID]; status != OrderUnplaced {
+		t.Errorf("Order should be canceled: %s", status)
+	}
+}
+
+func TestTracker_RejectCancelWhileModifying(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := NewOrder("MODIFYING", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.RejectCancel(order.ClientID, now, "too late"); e != nil {
+		t.Fatal(e)
+	}
+	if status := tracker.GetOrderStatuses([]OrderClientID{order.ClientID})[order.ClientID]; status != OrderModifying {
+		t.Errorf("Should return to pending modification: %s", status)
+	}
+	from, e := tracker.OrderMoveConfirmed(order.ClientID, now, 101)
+	if e != nil || from != OrderModifying {
+		t.Fatalf("Should accept modification after rejected cancel: %s, %v", from, e)
+	}
+	status, gotOrder, _, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil || status != OrderPlaced || gotOrder.Price != 101 {
+		t.Errorf("Should place modified order: %s, %+v, %v", status, gotOrder, e)
+	}
+}
+
+func TestTracker_GetExecutionReport(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected(order.ClientID, now, "no funds"); e != nil {
+		t.Fatal(e)
+	}
+	got, e := tracker.GetExecutionReport(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := ExecutionReport{Kind: ReportRejected, Time: now, Message: "no funds"}
+	if got != want {
+		t.Errorf("Unexpected execution report: %+v", got)
+	}
+	if _, e := tracker.GetExecutionReport("unknown"); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+}
+
+func TestTracker_GetCurrentStatus(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	wantOrder := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(wantOrder); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(wantOrder.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	gotStatus, gotOrder, gotReport, e := tracker.GetCurrentStatus(wantOrder.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if gotStatus != OrderPlaced {
+		t.Errorf("Order should have 'Placed' status: %s", gotStatus)
+	}
+	if gotOrder != wantOrder {
+		t.Errorf("Unexpected order: %+v", gotOrder)
+	}
+	if gotReport.Kind != ReportPlaced || !gotReport.Time.Equal(now) {
+		t.Errorf("Unexpected execution report: %+v", gotReport)
+	}
+	if _, _, _, e := tracker.GetCurrentStatus("unknown"); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+}
+
+func TestTracker_DisplayedRemaining(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	iceberg := NewOrder("ICEBERG", ExchangeBinance, "TEST", 25, 100)
+	iceberg.DisplayQuantity = 10
+	plain := NewOrder("PLAIN", ExchangeBinance, "TEST", 25, 100)
+	for _, order := range []Order{iceberg, plain} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if got, e := tracker.DisplayedRemaining(iceberg.ClientID); e != nil || got != 10 {
+		t.Errorf("Should display the first slice: %v, %v", got, e)
+	}
+	fills := []struct {
+		amount      uint64
+		icebergWant uint64
+		plainWant   uint64
+	}{
+		{4, 6, 21},  // first slice partially executed
+		{6, 10, 15}, // first slice exhausted, second refreshed
+		{7, 3, 8},   // second slice partially executed
+		{3, 5, 5},   // last slice refreshed from the hidden remainder
+		{5, 0, 0},   // order filled
+	}
+	for _, fill := range fills {
+		for _, order := range []Order{iceberg, plain} {
+			if _, e := tracker.OrderFilled(order.ClientID, now, fill.amount, 100); e != nil {
+				t.Fatal(e)
+			}
+		}
+		if got, _ := tracker.DisplayedRemaining(iceberg.ClientID); got != fill.icebergWant {
+			t.Errorf("Unexpected iceberg displayed amount after fill of %d: %v != %v", fill.amount, got, fill.icebergWant)
+		}
+		if got, _ := tracker.DisplayedRemaining(plain.ClientID); got != fill.plainWant {
+			t.Errorf("Unexpected displayed amount after fill of %d: %v != %v", fill.amount, got, fill.plainWant)
+		}
+	}
+	if _, e := tracker.DisplayedRemaining("unknown"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+}
+
+func TestTracker_FillRatio(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	if _, e := tracker.FillRatio("unknown"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+	order := NewOrder("RATIO", ExchangeBinance, "TEST", 4, 100)
+	zero := NewOrder("ZERO", ExchangeBinance, "TEST", 0, 100)
+	for _, o := range []Order{order, zero} {
+		if e := tracker.OrderPlacing(o); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if got, e := tracker.FillRatio(order.ClientID); e != nil || got != 0 {
+		t.Errorf("Unexpected ratio of unfilled order: %v, %v", got, e)
+	}
+	for _, want := range []float64{0.25, 0.5, 0.75, 1} {
+		if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); e != nil {
+			t.Fatal(e)
+		}
+		if got, e := tracker.FillRatio(order.ClientID); e != nil || got != want {
+			t.Errorf("Unexpected fill ratio: %v != %v, %v", got, want, e)
+		}
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); !errors.Is(e, ErrOverfill) {
+		t.Errorf("Should reject overfill: %v", e)
+	}
+	if got, e := tracker.FillRatio(zero.ClientID); e != nil || got != 0 {
+		t.Errorf("Unexpected ratio of zero amount order: %v, %v", got, e)
+	}
+}
+
+func TestTracker_PriceImprovement(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	buy := NewOrder("BUY", ExchangeBinance, "TEST", 10, 100)
+	buy.Side = SideBuy
+	sell := NewOrder("SELL", ExchangeBinance, "TEST", 10, 100)
+	sell.Side = SideSell
+	for _, order := range []Order{buy, sell} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.PriceImprovement(order.ClientID); e == nil {
+			t.Error("Should fail without fills")
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	fills := []struct {
+		amount uint64
+		price  Price
+	}{
+		{2, 98},  // better for buy, worse for sell
+		{3, 100}, // at the order price
+		{1, 103}, // worse for buy, better for sell
+	}
+	for _, fill := range fills {
+		for _, order := range []Order{buy, sell} {
+			if _, e := tracker.OrderFilled(order.ClientID, now, fill.amount, fill.price); e != nil {
+				t.Fatal(e)
+			}
+		}
+	}
+	if got, e := tracker.PriceImprovement(buy.ClientID); e != nil || got != 4 {
+		t.Errorf("Unexpected buy price improvement: %v, %v", got, e)
+	}
+	if got, e := tracker.PriceImprovement(sell.ClientID); e != nil || got != 3 {
+		t.Errorf("Unexpected sell price improvement: %v, %v", got, e)
+	}
+
+	market := NewMarketOrder("MARKET", ExchangeBinance, "TEST", 1)
+	if e := tracker.OrderPlacing(market); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(market.ClientID, now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.PriceImprovement(market.ClientID); e == nil {
+		t.Error("Should fail for market order")
+	}
+	if _, e := tracker.PriceImprovement("unknown"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+}
+
+func TestTracker_AverageFillPrice(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := NewOrder("1", ExchangeBinance, "TEST", 100, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, filled := tracker.AverageFillPrice(order.ClientID); filled {
+		t.Error("Should not have average price without fills")
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 10, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, now, 110); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 30, 110); e != nil {
+		t.Fatal(e)
+	}
+	got, filled := tracker.AverageFillPrice(order.ClientID)
+	if !filled {
+		t.Fatal("Should have average price after fills")
+	}
+	if got != (10*100+30*110)/40 {
+		t.Errorf("Unexpected average fill price: %v", got)
+	}
+	if _, filled := tracker.AverageFillPrice("unknown"); filled {
+		t.Error("Should not have average price for unknown order")
+	}
+}
+
+func TestTracker_Slippage(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	above := NewOrder("above", ExchangeBinance, "TEST", 100, 100)
+	below := NewOrder("below", ExchangeBinance, "TEST", 100, 100)
+	for _, order := range []Order{above, below} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.Slippage(above.ClientID); e == nil {
+		t.Error("Should return error without fills")
+	}
+	if _, e := tracker.OrderFilled(above.ClientID, now, 10, 103); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(below.ClientID, now, 10, 98); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.Slippage(above.ClientID); e != nil || got != 3 {
+		t.Errorf("Unexpected slippage: %v, %v", got, e)
+	}
+	if got, e := tracker.Slippage(below.ClientID); e != nil || got != -2 {
+		t.Errorf("Unexpected slippage: %v, %v", got, e)
+	}
+	market := NewMarketOrder("market", ExchangeBinance, "TEST", 10)
+	if e := tracker.OrderPlacing(market); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(market.ClientID, now, 10, 98); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.Slippage(market.ClientID); e == nil {
+		t.Error("Should return error for market order")
+	}
+	if _, e := tracker.Slippage("unknown"); e == nil {
+		t.Error("Should return error for unknown order")
+	}
+}
+
+func TestTracker_SlippageBySide(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	fills := []struct {
+		side  OrderSide
+		price Price
+		want  int64
+	}{
+		{SideBuy, 103, 3},
+		{SideBuy, 98, -2},
+		{SideSell, 103, -3},
+		{SideSell, 98, 2},
+	}
+	for i, fill := range fills {
+		order := NewOrder(OrderClientID(fmt.Sprint(i)), ExchangeBinance, "TEST", 10, 100)
+		order.Side = fill.side
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, 10, fill.price); e != nil {
+			t.Fatal(e)
+		}
+		if got, e := tracker.Slippage(order.ClientID); e != nil || got != fill.want {
+			t.Errorf("Unexpected slippage of %v order filled at %v: %v, %v", fill.side, fill.price, got, e)
+		}
+	}
+}
+
+func TestTracker_OrderPlacingBatch(t *testing.T) {
+	tracker := NewTracker()
+	existing := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(existing); e != nil {
+		t.Fatal(e)
+	}
+	first := GenerateOrderWithSymbol("TEST")
+	second := GenerateOrderWithSymbol("TEST")
+	errs := tracker.OrderPlacingBatch([]Order{first, existing, second, first})
+	if len(errs) != 4 {
+		t.Fatalf("Should return error for every order: %v", errs)
+	}
+	for i, wantFailed := range []bool{false, true, false, true} {
+		if (errs[i] != nil) != wantFailed {
+			t.Errorf("Unexpected error for order %d: %v", i, errs[i])
+		}
+	}
+	if tracker.GetOrdersCount() != 3 {
+		t.Errorf("Should place new orders only: %v", tracker.GetOrdersCount())
+	}
+}
+
+func TestTracker_Reset(t *testing.T) {
+	tracker := NewTracker(WithValidation())
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "TEST", 1, 2); e != nil {
+		t.Fatal(e)
+	}
+	tracker.Reset()
+	if tracker.GetOrdersCount() != 0 {
+		t.Error("Should not contain orders after reset")
+	}
+	if len(tracker.exchanges) != 0 {
+		t.Error("Should not contain market data after reset")
+	}
+	if _, e := tracker.PushQuote(ExchangeNone, "TEST", 1, 2); !errors.Is(e, ErrInvalidQuote) {
+		t.Error("Should keep configuration after reset")
+	}
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Errorf("Should be usable after reset: %v", e)
+	}
+}
+
+func TestTracker_OrderPlacingValidation(t *testing.T) {
+	valid := NewOrder("1", ExchangeBinance, "TEST", 10, 100)
+	tests := []struct {
+		name   string
+		modify func(*Order)
+	}{
+		{"empty client id", func(o *Order) { o.ClientID = "" }},
+		{"none exchange", func(o *Order) { o.Exchange = ExchangeNone }},
+		{"empty symbol", func(o *Order) { o.Symbol = "" }},
+		{"zero amount", func(o *Order) { o.Amount = 0 }},
+		{"zero price", func(o *Order) { o.Price = 0 }},
+		{"GTD without expiration", func(o *Order) { o.TimeInForce = TifGTD }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			order := valid
+			test.modify(&order)
+			tracker := NewTracker(WithValidation())
+			if e := tracker.OrderPlacing(order); !errors.Is(e, ErrInvalidOrder) {
+				t.Errorf("Should reject invalid order: %v", e)
+			}
+			if tracker.GetOrdersCount() != 0 {
+				t.Error("Should not register invalid order")
+			}
+			if e := NewTracker().OrderPlacing(order); e != nil {
+				t.Errorf("Should accept any order without validation: %v", e)
+			}
+		})
+	}
+	if e := NewTracker(WithValidation()).OrderPlacing(valid); e != nil {
+		t.Errorf("Should accept valid order: %v", e)
+	}
+	market := NewMarketOrder("2", ExchangeBinance, "TEST", 10)
+	if e := NewTracker(WithValidation()).OrderPlacing(market); e != nil {
+		t.Errorf("Should accept market order without price: %v", e)
+	}
+}
+
+func TestTracker_Clone(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+
+	cloned := tracker.Clone()
+	if e := cloned.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := cloned.OrderFilledWithTradeID(order.ClientID, now, "t1", 1, order.Price); e != nil {
+		t.Fatal(e)
+	}
+	if e := cloned.OrderPlacing(GenerateOrderWithSymbol("OTHER")); e != nil {
+		t.Fatal(e)
+	}
+	symbolContext := cloned.exchanges[order.Exchange][order.Symbol]
+	if symbolContext.orders[order.ClientID] != cloned.orders[order.ClientID] {
+		t.Error("Cloned market data should point to cloned order")
+	}
+
+	if tracker.GetOrdersCount() != 1 {
+		t.Error("Original should not get orders placed in the clone")
+	}
+	status, _, report, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderPlaced || report.Kind != ReportPlaced {
+		t.Errorf("Original status should be unchanged: %s, %+v", status, report)
+	}
+	if history, _ := tracker.GetOrderHistory(order.ClientID); len(history) != 2 {
+		t.Errorf("Original history should be unchanged: %v", history)
+	}
+	if applied, _ := tracker.OrderFilledWithTradeID(order.ClientID, now, "t1", 1, order.Price); !applied {
+		t.Error("Original should not see trade ids applied to the clone")
+	}
+	if cloned.Stats().Placing != 2 || tracker.Stats().Placing != 1 {
+		t.Error("Stats should be copied and then counted independently")
+	}
+}
+
+func TestTracker_Inventory(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	fills := []struct {
+		exchange ExchangeID
+		symbol   SymbolID
+		side     OrderSide
+		amount   uint64
+	}{
+		{ExchangeBinance, "BTC", SideBuy, 10},
+		{ExchangeBinance, "BTC", SideBuy, 5},
+		{ExchangeBinance, "BTC", SideSell, 7},
+		{ExchangeBinance, "BTC", SideNone, 1},
+		{ExchangeBinance, "ETH", SideBuy, 100},
+		{ExchangeKraken, "BTC", SideSell, 100},
+	}
+	for _, fill := range fills {
+		order := NewOrder(GenerateClientOrderID(), fill.exchange, fill.symbol, fill.amount, 1)
+		order.Side = fill.side
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, fill.amount, 1); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderPlacing(NewOrder("unfilled", ExchangeBinance, "BTC", 50, 1)); e != nil {
+		t.Fatal(e)
+	}
+	net, gross := tracker.Inventory(ExchangeBinance, "BTC")
+	if net != 8 || gross != 23 {
+		t.Errorf("Unexpected inventory: net %v, gross %v", net, gross)
+	}
+	net, gross = tracker.Inventory(ExchangeKraken, "BTC")
+	if net != -100 || gross != 100 {
+		t.Errorf("Unexpected inventory: net %v, gross %v", net, gross)
+	}
+	if net, gross := tracker.Inventory(ExchangeKraken, "ETH"); net != 0 || gross != 0 {
+		t.Errorf("Should not have inventory without fills: net %v, gross %v", net, gross)
+	}
+}
+
+func TestTracker_InvalidTransitionKeepsReport(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start); e != nil {
+		t.Fatal(e)
+	}
+	wantReport := ExecutionReport{Kind: ReportPlaced, Time: start}
+	later := start.Add(time.Minute)
+	transitions := map[string]func() error{
+		"OrderPlaceConfirmed": func() error { _, e := tracker.OrderPlaceConfirmed(order.ClientID, start.Add(-time.Minute)); return e },
+		"OrderRejected":       func() error { _, e := tracker.OrderRejected(order.ClientID, later, "rejected"); return e },
+		"OrderMoveConfirmed":  func() error { _, e := tracker.OrderMoveConfirmed(order.ClientID, later, 1); return e },
+		"OrderCancelConfirmed": func() error {
+			_, e := tracker.OrderCancelConfirmedWithReason(order.ClientID, later, "canceled")
+			return e
+		},
+	}
+	for name, transition := range transitions {
+		if e := transition(); e == nil {
+			t.Errorf("%s should fail for placed order", name)
+		}
+		if got, _ := tracker.GetExecutionReport(order.ClientID); got != wantReport {
+			t.Errorf("%s should not modify report on failure: %+v", name, got)
+		}
+	}
+}
+
+func TestTracker_OrderPartialCancelConfirmed(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("PARTIAL", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, 1); e == nil {
+		t.Error("Should not cancel partially order being placed")
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, 11); e == nil {
+		t.Error("Should not cancel more than remaining amount")
+	}
+	if _, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, 4); e != nil {
+		t.Fatal(e)
+	}
+	status, current, report, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderPlaced || current.Amount != 6 || report.Kind != ReportCanceled || report.Amount != 4 {
+		t.Errorf("Should keep residual order placed: %v %+v %+v", status, current, report)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, 6); e != nil {
+		t.Fatal(e)
+	}
+	status, current, _, _ = tracker.GetCurrentStatus(order.ClientID)
+	if status != OrderUnplaced || current.Amount != 0 {
+		t.Errorf("Should unplace order canceled completely: %v %+v", status, current)
+	}
+	if tracker.Stats().Canceled != 1 {
+		t.Error("Should count complete cancellation")
+	}
+}
+
+func TestTracker_GetOrder(t *testing.T) {
+	tracker := NewTracker()
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	got, e := tracker.GetOrder(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got != order {
+		t.Errorf("Should return placed order: %+v", got)
+	}
+	if _, e := tracker.GetOrder("UNKNOWN"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound: %v", e)
+	}
+	if e := tracker.OrderMoving("UNKNOWN"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound on transition: %v", e)
+	}
+}
+
+func TestTracker_GetOriginalOrder(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("ORIGINAL", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderMoveConfirmed(order.ClientID, now, 105); e != nil {
+		t.Fatal(e)
+	}
+
+	live, e := tracker.GetOrder(order.ClientID)
+	if e != nil || live.Price != 105 {
+		t.Errorf("Should update the live order on modification: %+v, %v", live, e)
+	}
+	original, e := tracker.GetOriginalOrder(order.ClientID)
+	if e != nil || original != order {
+		t.Errorf("Should keep the original order on modification: %+v, %v", original, e)
+	}
+	if _, e := tracker.GetOriginalOrder("UNKNOWN"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound: %v", e)
+	}
+}
+
+func TestTracker_OrderReplacing(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("REPLACE", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(order); e == nil {
+		t.Error("Should not re-place active order")
+	}
+	if _, e := tracker.OrderRejected(order.ClientID, now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	replaced := order
+	replaced.Price = 101
+	if e := tracker.OrderPlacing(replaced); e != nil {
+		t.Fatal(e)
+	}
+	status, current, report, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderPlacing || current != replaced || report != (ExecutionReport{}) {
+		t.Errorf("Should start re-placed order over: %v %+v %+v", status, current, report)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	history, e := tracker.GetOrderHistory(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(history) != 7 {
+		t.Errorf("Should keep history of previous placements: %+v", history)
+	}
+	if stats := tracker.Stats(); stats.Placing != 3 {
+		t.Errorf("Should count every placement: %+v", stats)
+	}
+}
+
+func TestTracker_ZeroAmountFill(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	order := NewOrder("ZERO", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	for range 2 {
+		if _, e := tracker.OrderFilled(order.ClientID, now, 0, 100); !errors.Is(e, ErrInvalidFill) {
+			t.Errorf("Should reject zero amount fill: %v", e)
+		}
+	}
+	if _, e := tracker.OrderFilledWithTradeID(order.ClientID, now, "T1", 0, 100); !errors.Is(e, ErrInvalidFill) {
+		t.Errorf("Should reject zero amount fill with trade ID: %v", e)
+	}
+	if _, e := tracker.OrderFilledWithFee(order.ClientID, now, 0, 100, 1); !errors.Is(e, ErrInvalidFill) {
+		t.Errorf("Should reject zero amount fill with fee: %v", e)
+	}
+	if report, _ := tracker.GetExecutionReport(order.ClientID); report.Kind != ReportNone {
+		t.Errorf("Should not change the report: %+v", report)
+	}
+}
+
+func TestTracker_Overfill(t *testing.T) {
+	tracker := NewTracker(WithValidation(), WithStrictFills())
+	now := time.Now()
+	order := NewOrder("OVERFILL", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlaced(order, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 25, 100); !errors.Is(e, ErrOverfill) {
+		t.Errorf("Should reject fill exceeding the order amount: %v", e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 6, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 5, 100); !errors.Is(e, ErrOverfill) {
+		t.Errorf("Should reject fill exceeding the remaining amount: %v", e)
+	}
+	if report, _ := tracker.GetExecutionReport(order.ClientID); report.Amount != 6 {
+		t.Errorf("Should not apply rejected fills: %+v", report)
+	}
+	if violations := tracker.CheckInvariants(); len(violations) != 0 {
+		t.Errorf("Should keep invariants: %v", violations)
+	}
+}
+
+func TestTracker_OrderFilledWithFee(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("FEES", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if complete, e := tracker.OrderFilledWithFee(order.ClientID, now, 4, 100, 5); e != nil || complete {
+		t.Fatalf("Should fill partially: %v, %v", complete, e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 2, 100); e != nil {
+		t.Fatal(e)
+	}
+	if complete, e := tracker.OrderFilledWithFee(order.ClientID, now, 4, 100, -8); e != nil || !complete {
+		t.Fatalf("Should fill completely: %v, %v", complete, e)
+	}
+	fees, e := tracker.GetFees(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if fees != -3 {
+		t.Errorf("Should accumulate fees and rebates: %d", fees)
+	}
+	if report, _ := tracker.GetExecutionReport(order.ClientID); report.Amount != 10 || report.Fee != -3 {
+		t.Errorf("Should aggregate fills with fees: %+v", report)
+	}
+	if _, e := tracker.GetFees("UNKNOWN"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound: %v", e)
+	}
+}
+
+func TestTracker_GetOrderStatuses(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	var clids []OrderClientID
+	for i := range 10 {
+		order := GenerateOrderWithSymbol("TEST")
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if i%2 == 0 {
+			if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+				t.Fatal(e)
+			}
+		}
+		clids = append(clids, order.ClientID)
+	}
+	statuses := tracker.GetOrderStatuses(append(clids, "UNKNOWN"))
+	if len(statuses) != len(clids) {
+		t.Errorf("Should omit unknown orders: %v", statuses)
+	}
+	for _, clid := range clids {
+		status, _, _, e := tracker.GetCurrentStatus(clid)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if statuses[clid] != status {
+			t.Errorf("Should match individual lookup (clid %v): %v != %v", clid, statuses[clid], status)
+		}
+	}
+}
+
+func TestTracker_Reconcile(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	orders := []Order{
+		NewOrder("A", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("B", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("C", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("D", ExchangeKraken, "TEST", 1, 100),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderCancelling("C"); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderCancelConfirmed("C", now); e != nil {
+		t.Fatal(e)
+	}
+
+	missingLocally, missingRemotely := tracker.Reconcile(ExchangeBinance, []OrderClientID{"E", "C", "A", "D"})
+	if !slices.Equal(missingLocally, []OrderClientID{"C", "D", "E"}) {
+		t.Errorf("Should report live orders not active locally: %v", missingLocally)
+	}
+	if !slices.Equal(missingRemotely, []OrderClientID{"B"}) {
+		t.Errorf("Should report active orders not live on exchange: %v", missingRemotely)
+	}
+}
+
+func TestTracker_WithClock(t *testing.T) {
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker := NewTracker(WithClock(func() time.Time { return start }))
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	stale := tracker.FindStaleOrders(OrderPlacing, time.Second, start.Add(2*time.Second))
+	if !slices.Equal(stale, []OrderClientID{order.ClientID}) {
+		t.Errorf("Should timestamp placing with the clock: %v", stale)
+	}
+}
+
+func TestTracker_SlippageTicks(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("TICKS", ExchangeBinance, "BTCUSDT", 10, 10000)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, now, 10, 9950); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.SlippageTicks(order.ClientID); e == nil {
+		t.Error("Should fail for symbol without tick size")
+	}
+
+	spec := SymbolSpec{PriceScale: 2, AmountScale: 3, TickSize: 25}
+	tracker.RegisterSymbol(ExchangeBinance, "BTCUSDT", spec)
+	if got, ok := tracker.GetSymbolSpec(ExchangeBinance, "BTCUSDT"); !ok || got != spec {
+		t.Errorf("Should return registered spec: %+v", got)
+	}
+	ticks, e := tracker.SlippageTicks(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if ticks != -2 {
+		t.Errorf("Should express slippage in ticks: %d", ticks)
+	}
+
+	tracker.Reset()
+	if _, ok := tracker.GetSymbolSpec(ExchangeBinance, "BTCUSDT"); !ok {
+		t.Error("Should keep symbol specs on reset")
+	}
+}
+
+func TestTracker_OrderRejectedReturnsPreviousStatus(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	placing := GenerateOrderWithSymbol("TEST")
+	moving := GenerateOrderWithSymbol("TEST")
+	for _, order := range []Order{placing, moving} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	from, e := tracker.OrderPlaceConfirmed(moving.ClientID, now)
+	if e != nil || from != OrderPlacing {
+		t.Errorf("Should return status before confirmation: %v, %v", from, e)
+	}
+	if e := tracker.OrderMoving(moving.ClientID); e != nil {
+		t.Fatal(e)
+	}
+
+	from, e = tracker.OrderRejected(placing.ClientID, now, "rejected")
+	if e != nil || from != OrderPlacing {
+		t.Errorf("Should return placing status: %v, %v", from, e)
+	}
+	from, e = tracker.OrderRejected(moving.ClientID, now, "rejected")
+	if e != nil || from != OrderModifying {
+		t.Errorf("Should return modifying status: %v, %v", from, e)
+	}
+	from, e = tracker.OrderRejected(moving.ClientID, now, "rejected")
+	if e == nil || from != OrderPlaced {
+		t.Errorf("Should return current status on failure: %v, %v", from, e)
+	}
+	from, e = tracker.OrderCancelConfirmed("UNKNOWN", now)
+	if !errors.Is(e, ErrOrderNotFound) || from != OrderUnplaced {
+		t.Errorf("Should return OrderUnplaced for unknown order: %v, %v", from, e)
+	}
+}
+
+func TestTracker_OrderExpired(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderExpired(order.ClientID, now); e == nil {
+		t.Error("Should not expire order being placed")
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	from, e := tracker.OrderExpired(order.ClientID, now)
+	if e != nil || from != OrderPlaced {
+		t.Fatalf("Should expire placed order: %v, %v", from, e)
+	}
+	status, _, report, e := tracker.GetCurrentStatus(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status != OrderExpired || report != (ExecutionReport{Kind: ReportExpired, Time: now}) {
+		t.Errorf("Should be expired with expiry report: %v, %+v", status, report)
+	}
+	if status.String() != "Expired" {
+		t.Errorf("Unexpected status string: %s", status)
+	}
+	if stats := tracker.Stats(); stats.Expired != 1 {
+		t.Errorf("Should count expiry: %+v", stats)
+	}
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Errorf("Should re-place expired order: %v", e)
+	}
+	history, e := tracker.GetOrderHistory(order.ClientID)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if last := history[len(history)-1]; last.From != OrderExpired || last.To != OrderPlacing {
+		t.Errorf("Should record re-placement from the expired status: %+v", last)
+	}
+}
+
+func TestTracker_OrdersAtPrice(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	orders := []Order{
+		NewOrder("A", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("B", ExchangeBinance, "TEST", 1, 102),
+		NewOrder("C", ExchangeBinance, "TEST", 1, 97),
+		NewOrder("D", ExchangeBinance, "OTHER", 1, 100),
+		NewOrder("E", ExchangeKraken, "TEST", 1, 100),
+		NewOrder("F", ExchangeBinance, "TEST", 1, 100),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("F", now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	if got := tracker.OrdersAtPrice(ExchangeBinance, "TEST", 100, 0); !slices.Equal(got, []OrderClientID{"A"}) {
+		t.Errorf("Should find active orders at exact price: %v", got)
+	}
+	if got := tracker.OrdersAtPrice(ExchangeBinance, "TEST", 100, 2); !slices.Equal(got, []OrderClientID{"A", "B"}) {
+		t.Errorf("Should find active orders within tolerance: %v", got)
+	}
+	if got := tracker.OrdersAtPrice(ExchangeBinance, "TEST", 99, 2); !slices.Equal(got, []OrderClientID{"A", "C"}) {
+		t.Errorf("Should find active orders below and above price: %v", got)
+	}
+}
+
+func TestTracker_MultipleOrdersPerSymbol(t *testing.T) {
+	tracker := NewTracker()
+	first := NewOrder("FIRST", ExchangeBinance, "TEST", 1, 100)
+	second := NewOrder("SECOND", ExchangeBinance, "TEST", 1, 101)
+	for _, order := range []Order{first, second} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	orders := tracker.exchanges[ExchangeBinance]["TEST"].orders
+	if len(orders) != 2 || orders[first.ClientID] == nil || orders[second.ClientID] == nil {
+		t.Errorf("Should track every order on symbol: %v", orders)
+	}
+
+	cloned := tracker.Clone()
+	clonedOrders := cloned.exchanges[ExchangeBinance]["TEST"].orders
+	if len(clonedOrders) != 2 || clonedOrders[first.ClientID] != cloned.orders[first.ClientID] {
+		t.Errorf("Should track cloned orders in cloned market data: %v", clonedOrders)
+	}
+}
+
+func TestTracker_PurgeCompleted(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	rejected := NewOrder("REJECTED", ExchangeBinance, "TEST", 1, 100)
+	filled := NewOrder("FILLED", ExchangeBinance, "TEST", 1, 100)
+	late := NewOrder("LATE", ExchangeBinance, "TEST", 1, 100)
+	active := NewOrder("ACTIVE", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{rejected, filled, late, active} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected(rejected.ClientID, now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(filled.ClientID, now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected(late.ClientID, now.Add(time.Hour), "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	if purged := tracker.PurgeCompleted(now.Add(time.Minute)); purged != 2 {
+		t.Errorf("Should purge completed orders before time: %d", purged)
+	}
+	if tracker.GetOrdersCount() != 2 {
+		t.Errorf("Should keep late and active orders: %d", tracker.GetOrdersCount())
+	}
+	orders := tracker.exchanges[ExchangeBinance]["TEST"].orders
+	if len(orders) != 2 || orders[late.ClientID] == nil || orders[active.ClientID] == nil {
+		t.Errorf("Should remove purged orders from market data: %v", orders)
+	}
+	if e := tracker.OrderPlacing(filled); e != nil {
+		t.Errorf("Should place purged client ID again: %v", e)
+	}
+}
+
+func TestTracker_GetActiveOrders(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	for _, clid := range []OrderClientID{"PLACING", "PLACED", "MODIFYING", "CANCELING", "FILLED", "REJECTED", "EXPIRED"} {
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 100)); e != nil {
+			t.Fatal(e)
+		}
+		if clid == "PLACING" || clid == "FILLED" || clid == "REJECTED" {
+			continue
+		}
+		if _, e := tracker.OrderPlaceConfirmed(clid, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderMoving("MODIFYING"); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling("CANCELING"); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled("FILLED", now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderRejected("REJECTED", now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderExpired("EXPIRED", now); e != nil {
+		t.Fatal(e)
+	}
+
+	want := []OrderClientID{"CANCELING", "MODIFYING", "PLACED", "PLACING"}
+	if got := tracker.GetActiveOrders(); !slices.Equal(got, want) {
+		t.Errorf("Should return active orders only: %v", got)
+	}
+	if got := tracker.GetActiveOrdersCount(); got != len(want) {
+		t.Errorf("Should count active orders only: %d", got)
+	}
+}
+
+func TestTracker_WithInitialCapacity(t *testing.T) {
+	for _, tracker := range []*Tracker{
+		NewTracker(WithInitialCapacity(16, 2)),
+		NewTracker(WithInitialCapacity(-1, -1)),
+	} {
+		if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+			t.Fatal(e)
+		}
+		tracker.Reset()
+		if e := tracker.OrderPlacing(GenerateOrderWithSymbol("TEST")); e != nil {
+			t.Fatal(e)
+		}
+		if tracker.GetOrdersCount() != 1 {
+			t.Errorf("Should track orders after reset: %d", tracker.GetOrdersCount())
+		}
+	}
+}
+
+func TestTracker_WithMaxOrders(t *testing.T) {
+	tracker := NewTracker(WithMaxOrders(2))
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	first := GenerateOrderWithSymbol("TEST")
+	second := GenerateOrderWithSymbol("TEST")
+	for _, order := range []Order{first, second} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	third := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(third); !errors.Is(e, ErrTooManyOrders) {
+		t.Fatalf("Should return ErrTooManyOrders at limit: %v", e)
+	}
+
+	if _, e := tracker.OrderRejected(first.ClientID, now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderPlacing(third); e != nil {
+		t.Fatalf("Should not count terminal orders toward limit: %v", e)
+	}
+	if e := tracker.OrderPlacing(first); !errors.Is(e, ErrTooManyOrders) {
+		t.Fatalf("Should not re-place order at limit: %v", e)
+	}
+
+	if _, e := tracker.OrderFilled(second.ClientID, now, second.Amount, second.Price); e != nil {
+		t.Fatal(e)
+	}
+	if purged := tracker.PurgeCompleted(now.Add(time.Second)); purged != 2 {
+		t.Fatalf("Should purge completed orders: %d", purged)
+	}
+	if e := tracker.OrderPlacing(first); e != nil {
+		t.Errorf("Should place order after purge: %v", e)
+	}
+}
+
+func TestTracker_MidPrice(t *testing.T) {
+	tracker := NewTracker()
+	if e := tracker.OrderPlacing(NewOrder("A", ExchangeBinance, "TEST", 1, 100)); e != nil {
+		t.Fatal(e)
+	}
+	if _, ok := tracker.MidPrice(ExchangeBinance, "TEST"); ok {
+		t.Error("Should have no mid price without quote")
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "TEST", 99, 102); e != nil {
+		t.Fatal(e)
+	}
+	if mid, ok := tracker.MidPrice(ExchangeBinance, "TEST"); !ok || mid != 100 {
+		t.Errorf("Unexpected mid price: %v, %v", mid, ok)
+	}
+	if _, ok := tracker.MidPrice(ExchangeKraken, "TEST"); ok {
+		t.Error("Should have no mid price for another exchange")
+	}
+}
+
+func TestTracker_GetMarketQuote(t *testing.T) {
+	tracker := NewTracker()
+	if _, ok := tracker.GetMarketQuote(ExchangeBinance, "TEST"); ok {
+		t.Error("Should have no quote before push")
+	}
+	if _, e := tracker.PushQuoteWithSizes(ExchangeBinance, "TEST", 99, 102, 5, 7); e != nil {
+		t.Fatal(e)
+	}
+	want := MarketQuote{Bid: 99, Ask: 102, BidSize: 5, AskSize: 7}
+	if got, ok := tracker.GetMarketQuote(ExchangeBinance, "TEST"); !ok || got != want {
+		t.Errorf("Unexpected quote: %+v, %v", got, ok)
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "TEST", 100, 101); e != nil {
+		t.Fatal(e)
+	}
+	want = MarketQuote{Bid: 100, Ask: 101}
+	if got, ok := tracker.GetMarketQuote(ExchangeBinance, "TEST"); !ok || got != want {
+		t.Errorf("Should reset sizes on quote without sizes: %+v, %v", got, ok)
+	}
+	if _, ok := tracker.GetMarketQuote(ExchangeKraken, "TEST"); ok {
+		t.Error("Should have no quote for another exchange")
+	}
+}
+
+func TestTracker_WithStrictFills(t *testing.T) {
+	now := time.Now()
+	order := NewOrder("ORDER", ExchangeBinance, "TEST", 10, 100)
+	place := func(tracker *Tracker) error { return tracker.OrderPlacing(order) }
+	confirm := func(tracker *Tracker) error { _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); return e }
+	tests := []struct {
+		status OrderStatus
+		steps  []func(*Tracker) error
+		strict bool
+	}{
+		{OrderUnplaced, []func(*Tracker) error{place, func(tracker *Tracker) error {
+			return tracker.RejectPlace(order.ClientID, now, "")
+		}}, true},
+		{OrderPlacing, []func(*Tracker) error{place}, true},
+		{OrderPlaced, []func(*Tracker) error{place, confirm}, false},
+		{OrderModifying, []func(*Tracker) error{place, confirm, func(tracker *Tracker) error {
+			return tracker.OrderMoving(order.ClientID)
+		}}, false},
+		{OrderCanceling, []func(*Tracker) error{place, confirm, func(tracker *Tracker) error {
+			return tracker.OrderCancelling(order.ClientID)
+		}}, false},
+	}
+	fills := map[string]func(*Tracker) error{
+		"OrderFilled": func(tracker *Tracker) error {
+			_, e := tracker.OrderFilled(order.ClientID, now, 1, 100)
+			return e
+		},
+		"OrderFilledWithTradeID": func(tracker *Tracker) error {
+			_, e := tracker.OrderFilledWithTradeID(order.ClientID, now, "T1", 1, 100)
+			return e
+		},
+		"OrderFilledWithFee": func(tracker *Tracker) error {
+			_, e := tracker.OrderFilledWithFee(order.ClientID, now, 1, 100, 1)
+			return e
+		},
+	}
+	for _, test := range tests {
+		for name, fill := range fills {
+			for _, strict := range []bool{false, true} {
+				var opts []Option
+				if strict {
+					opts = append(opts, WithStrictFills())
+				}
+				tracker := NewTracker(opts...)
+				for _, step := range test.steps {
+					if e := step(tracker); e != nil {
+						t.Fatal(e)
+					}
+				}
+				e := fill(tracker)
+				if strict && test.strict {
+					if !errors.Is(e, ErrFillNotPlaced) {
+						t.Errorf("%s should reject fill in '%s', got %v", name, test.status, e)
+					}
+					var report ExecutionReport
+					if status, _ := tracker.GetOrderStatus(order.ClientID, nil, &report); status != test.status ||
+						report.Kind == ReportFilled {
+						t.Errorf("%s should not apply rejected fill in '%s'", name, test.status)
+					}
+				} else if e != nil {
+					t.Errorf("%s should accept fill in '%s' (strict %v): %v", name, test.status, strict, e)
+				}
+			}
+		}
+	}
+	if _, e := NewTracker(WithStrictFills()).OrderFilled("MISSING", now, 1, 100); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should not find order: %v", e)
+	}
+}
+
+func TestTracker_TrackedSymbols(t *testing.T) {
+	tracker := NewTracker()
+	if got := tracker.TrackedSymbols(); len(got) != 0 {
+		t.Errorf("Should track no symbols: %v", got)
+	}
+	if _, e := tracker.PushQuote(ExchangeBinance, "ETHUSDT", 99, 101); e != nil {
+		t.Fatal(e)
+	}
+	for _, order := range []Order{
+		NewOrder("A", ExchangeBinance, "BTCUSDT", 1, 100),
+		NewOrder("B", ExchangeBinance, "ETHUSDT", 1, 100),
+		NewOrder("C", ExchangeKraken, "BTCUSDT", 1, 100),
+		NewOrder("D", ExchangeKraken, "XRPUSDT", 1, 100),
+	} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.RejectPlace("D", time.Now(), ""); e != nil {
+		t.Fatal(e)
+	}
+	tracker.PurgeCompleted(time.Now().Add(time.Minute))
+
+	got := tracker.TrackedSymbols()
+	want := map[ExchangeID][]SymbolID{
+		ExchangeBinance: {"BTCUSDT", "ETHUSDT"},
+		ExchangeKraken:  {"BTCUSDT"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Unexpected tracked symbols: %v", got)
+	}
+	for exchange, symbols := range want {
+		if !slices.Equal(got[exchange], symbols) {
+			t.Errorf("Unexpected tracked symbols of %v: %v != %v", exchange, got[exchange], symbols)
+		}
+	}
+}
+
+func TestTracker_SymbolView(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	if _, ok := tracker.SymbolView(ExchangeBinance, "TEST"); ok {
+		t.Error("Should have no view of unknown symbol")
+	}
+	placed := NewOrder("PLACED", ExchangeBinance, "TEST", 10, 100)
+	placing := NewOrder("PLACING", ExchangeBinance, "TEST", 5, 101)
+	filled := NewOrder("FILLED", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{placed, placing, filled} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderPlaceConfirmed(placed.ClientID, now); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(placed.ClientID, now, 3, 100); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderFilled(filled.ClientID, now, 1, 100); e != nil {
+		t.Fatal(e)
+	}
+
+	view, ok := tracker.SymbolView(ExchangeBinance, "TEST")
+	if !ok || view.HasQuote || view.Mid != 0 || view.SpreadBps != 0 {
+		t.Errorf("Unexpected view without quote: %+v, %v", view, ok)
+	}
+	if _, e := tracker.PushQuoteWithSizes(ExchangeBinance, "TEST", 99, 101, 3, 4); e != nil {
+		t.Fatal(e)
+	}
+	view, ok = tracker.SymbolView(ExchangeBinance, "TEST")
+	if !ok || !view.HasQuote || view.Quote != (MarketQuote{Bid: 99, Ask: 101, BidSize: 3, AskSize: 4}) {
+		t.Errorf("Unexpected quote: %+v, %v", view, ok)
+	}
+	if view.Mid != 100 || view.SpreadBps != 200 {
+		t.Errorf("Unexpected mid and spread: %v, %v", view.Mid, view.SpreadBps)
+	}
+	want := []SymbolOrder{
+		{Status: OrderPlaced, Order: placed, Remaining: 7},
+		{Status: OrderPlacing, Order: placing, Remaining: 5},
+	}
+	if !slices.Equal(view.Orders, want) {
+		t.Errorf("Unexpected active orders: %+v", view.Orders)
+	}
+}
+
+func TestTracker_DetectSelfCross(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	place := func(clid OrderClientID, symbol SymbolID, side OrderSide, price Price) {
+		order := NewOrder(clid, ExchangeBinance, symbol, 1, price)
+		order.Side = side
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	place("BID", "TEST", SideBuy, 99)
+	place("ASK", "TEST", SideSell, 101)
+	place("OTHER", "OTHER", SideBuy, 105)
+	if pairs := tracker.DetectSelfCross(ExchangeBinance, "TEST"); pairs != nil {
+		t.Errorf("Should not detect crosses of quotes around the spread: %v", pairs)
+	}
+	if pairs := tracker.DetectSelfCross(ExchangeKraken, "TEST"); pairs != nil {
+		t.Errorf("Should not detect crosses on unknown exchange: %v", pairs)
+	}
+
+	place("HIGH_BID", "TEST", SideBuy, 101)
+	place("LOW_ASK", "TEST", SideSell, 98)
+	place("REJECTED", "TEST", SideBuy, 110)
+	if _, e := tracker.OrderRejected("REJECTED", now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	market := NewOrder("MARKET", ExchangeBinance, "TEST", 1, 0)
+	market.Side, market.Type = SideBuy, TypeMarket
+	if e := tracker.OrderPlacing(market); e != nil {
+		t.Fatal(e)
+	}
+
+	want := []CrossPair{
+		{Buy: "BID", Sell: "LOW_ASK"},
+		{Buy: "HIGH_BID", Sell: "ASK"},
+		{Buy: "HIGH_BID", Sell: "LOW_ASK"},
+	}
+	if pairs := tracker.DetectSelfCross(ExchangeBinance, "TEST"); !slices.Equal(pairs, want) {
+		t.Errorf("Unexpected crossing pairs: %v", pairs)
+	}
+}
+
+func TestTracker_RejectByIntent(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	placing := NewOrder("PLACING", ExchangeBinance, "TEST", 1, 100)
+	modifying := NewOrder("MODIFYING", ExchangeBinance, "TEST", 1, 100)
+	canceling := NewOrder("CANCELING", ExchangeBinance, "TEST", 1, 100)
+	for _, order := range []Order{placing, modifying, canceling} {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for _, order := range []Order{modifying, canceling} {
+		if _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := tracker.OrderMoving(modifying.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.OrderCancelling(canceling.ClientID); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := tracker.RejectModify(placing.ClientID, now, "wrong"); e == nil {
+		t.Error("Should not reject modification of order being placed")
+	}
+	if e := tracker.RejectCancel(modifying.ClientID, now, "wrong"); e == nil {
+		t.Error("Should not reject cancellation of order being modified")
+	}
+	if e := tracker.RejectPlace(canceling.ClientID, now, "wrong"); e == nil {
+		t.Error("Should not reject placement of order being canceled")
+	}
+
+	tests := []struct {
+		reject func(OrderClientID, time.Time, string) error
+		clid   OrderClientID
+		want   OrderStatus
+	}{
+		{tracker.RejectPlace, placing.ClientID, OrderUnplaced},
+		{tracker.RejectModify, modifying.ClientID, OrderPlaced},
+		{tracker.RejectCancel, canceling.ClientID, OrderPlaced},
+	}
+	for _, test := range tests {
+		if e := test.reject(test.clid, now, "rejected"); e != nil {
+			t.Fatal(e)
+		}
+		status, _, report, e := tracker.GetCurrentStatus(test.clid)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if status != test.want || report.Kind != ReportRejected || report.Message != "rejected" {
+			t.Errorf("Unexpected state after rejection (clid %v): %v, %+v", test.clid, status, report)
+		}
+	}
+	if stats := tracker.Stats(); stats.Rejected != 3 {
+		t.Errorf("Should count every rejection: %+v", stats)
+	}
+}
+
+func TestTracker_OrderRejectedWithCode(t *testing.T) {
+	tracker := NewTracker(WithEventRing(16))
+	now := time.Now()
+	for _, clid := range []OrderClientID{"THROTTLED", "UNCODED"} {
+		if e := tracker.OrderPlacing(NewOrder(clid, ExchangeBinance, "TEST", 1, 100)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	from, e := tracker.OrderRejectedWithCode("THROTTLED", now, RejectThrottled, "too many requests")
+	if e != nil || from != OrderPlacing {
+		t.Fatalf("Unexpected rejection: %v, %v", from, e)
+	}
+	_, _, report, e := tracker.GetCurrentStatus("THROTTLED")
+	if e != nil || report.Kind != ReportRejected || report.RejectCode != RejectThrottled ||
+		report.Message != "too many requests" {
+		t.Errorf("Unexpected report: %+v, %v", report, e)
+	}
+	if _, e := tracker.OrderRejected("UNCODED", now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+	if _, _, report, _ := tracker.GetCurrentStatus("UNCODED"); report.RejectCode != RejectUnknown {
+		t.Errorf("Should reject with unknown code: %+v", report)
+	}
+	if _, e := tracker.OrderRejectedWithCode("THROTTLED", now, RejectThrottled, ""); e == nil {
+		t.Error("Should not reject order not pending")
+	}
+	if _, e := tracker.OrderRejectedWithCode("unknown", now, RejectRiskLimit, ""); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should not find order: %v", e)
+	}
+
+	replayed, e := ReplayEvents(tracker.Events())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if _, _, report, _ := replayed.GetCurrentStatus("THROTTLED"); report.RejectCode != RejectThrottled {
+		t.Errorf("Should replay rejection code: %+v", report)
+	}
+}
+
+func TestTracker_SpreadBps(t *testing.T) {
+	tracker := NewTracker()
+	if _, ok := tracker.SpreadBps(ExchangeBinance, "TEST"); ok {
+		t.Error("Should have no spread without quote")
+	}
+	tests := []struct {
+		bid, ask Price
+		want     uint64
+		ok       bool
+	}{
+		{999999, 1000001, 0, true},
+		{99995, 100005, 1, true},
+		{9000, 11000, 2000, true},
+		{0, 2, 20000, true},
+		{math.MaxUint64 - 1, math.MaxUint64, 0, true},
+		{1, math.MaxUint64, 19999, true},
+		{101, 100, 0, false},
+		{0, 0, 0, false},
+	}
+	for _, test := range tests {
+		if _, e := tracker.PushQuote(ExchangeBinance, "TEST", test.bid, test.ask); e != nil {
+			t.Fatal(e)
+		}
+		got, ok := tracker.SpreadBps(ExchangeBinance, "TEST")
+		if got != test.want || ok != test.ok {
+			t.Errorf("Unexpected spread of %v/%v: %v, %v", test.bid, test.ask, got, ok)
+		}
+	}
+}
+
+func TestTracker_MarkExchangeDisconnected(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	orders := []Order{
+		NewOrder("A", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("B", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("C", ExchangeBinance, "TEST", 1, 100),
+		NewOrder("D", ExchangeKraken, "TEST", 1, 100),
+	}
+	for _, order := range orders {
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if _, e := tracker.OrderRejected("C", now, "rejected"); e != nil {
+		t.Fatal(e)
+	}
+
+	active, e := tracker.MarkExchangeDisconnected(ExchangeBinance)
+	if e != nil || !slices.Equal(active, []OrderClientID{"A", "B"}) {
+		t.Errorf("Should return active orders on exchange: %v", active)
+	}
+	if tracker.IsExchangeConnected(ExchangeBinance) || !tracker.IsExchangeConnected(ExchangeKraken) {
+		t.Error("Should mark only the exchange as disconnected")
+	}
+	order := NewOrder("E", ExchangeBinance, "TEST", 1, 100)
+	if e := tracker.OrderPlacing(order); !errors.Is(e, ErrExchangeDisconnected) {
+		t.Errorf("Should not place order on disconnected exchange: %v", e)
+	}
+
+	tracker.MarkExchangeReconnected(ExchangeBinance)
+	if !tracker.IsExchangeConnected(ExchangeBinance) {
+		t.Error("Should clear disconnected mark")
+	}
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Errorf("Should place order after reconnect: %v", e)
+	}
+}
+
+func TestTracker_OrderUnknown(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := GenerateOrderWithSymbol("TEST")
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if e := tracker.ResolveUnknown(order.ClientID, now, OrderPlaced); e == nil {
+		t.Error("Should not resolve order in known state")
+	}
+	from, e := tracker.MarkOrderUnknown(order.ClientID, now)
+	if e != nil || from != OrderPlacing {
+		t.Fatalf("Should mark active order unknown: %v, %v", from, e)
+	}
+	if status, _, _, _ := tracker.GetCurrentStatus(order.ClientID); status != OrderUnknown || status.String() != "Unknown" {
+		t.Errorf("Should be unknown: %v", status)
+	}
+	if tracker.GetActiveOrdersCount() != 1 {
+		t.Error("Unknown order should be considered active")
+	}
+	if _, e := tracker.MarkOrderUnknown(order.ClientID, now); e == nil {
+		t.Error("Should not mark unknown order twice")
+	}
+	if e := tracker.ResolveUnknown(order.ClientID, now, OrderModifying); e == nil {
+		t.Error("Should not resolve into unverified state")
+	}
+	if e := tracker.ResolveUnknown(order.ClientID, now, OrderPlaced); e != nil {
+		t.Fatal(e)
+	}
+	if status, _, _, _ := tracker.GetCurrentStatus(order.ClientID); status != OrderPlaced {
+		t.Errorf("Should resolve into verified state: %v", status)
+	}
+	if e := tracker.OrderCancelling(order.ClientID); e != nil {
+		t.Errorf("Should continue lifecycle after resolution: %v", e)
+	}
+}
+
+func TestTracker_WithVWAPRounding(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rounding VWAPRounding
+		want     Price
+	}{
+		{VWAPTruncate, 100},
+		{VWAPRoundHalfUp, 101},
+	}
+	for _, test := range tests {
+		tracker := NewTracker(WithVWAPRounding(test.rounding))
+		order := NewOrder("VWAP", ExchangeBinance, "TEST", 10, 101)
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		// VWAP of 1 × 100 and 1 × 101 is 100.5
+		if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); e != nil {
+			t.Fatal(e)
+		}
+		if _, e := tracker.OrderFilled(order.ClientID, now, 1, 101); e != nil {
+			t.Fatal(e)
+		}
+		report, e := tracker.GetExecutionReport(order.ClientID)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if report.Price != test.want {
+			t.Errorf("Unexpected VWAP with rounding %d: %v != %v", test.rounding, report.Price, test.want)
+		}
+	}
+}
+
+func TestTracker_VWAPWithoutDrift(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rounding VWAPRounding
+		want     Price
+	}{
+		{VWAPTruncate, 102},
+		{VWAPRoundHalfUp, 103},
+	}
+	for _, test := range tests {
+		tracker := NewTracker(WithVWAPRounding(test.rounding))
+		order := NewOrder("VWAP", ExchangeBinance, "TEST", 1000, 103)
+		if e := tracker.OrderPlacing(order); e != nil {
+			t.Fatal(e)
+		}
+		// VWAP of 1 × 100 and 99 × 103 is 102.97, aggregating the rounded VWAP
+		// fill by fill would get stuck at 101
+		if _, e := tracker.OrderFilled(order.ClientID, now, 1, 100); e != nil {
+			t.Fatal(e)
+		}
+		for range 99 {
+			if _, e := tracker.OrderFilled(order.ClientID, now, 1, 103); e != nil {
+				t.Fatal(e)
+			}
+		}
+		report, e := tracker.GetExecutionReport(order.ClientID)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if report.Amount != 100 || report.Price != test.want {
+			t.Errorf("Unexpected VWAP with rounding %d: %v != %v", test.rounding, report.Price, test.want)
+		}
+		if value, e := tracker.CumulativeFilledValue(order.ClientID); e != nil || value != "10297" {
+			t.Errorf("Unexpected cumulative filled value: %v, %v", value, e)
+		}
+	}
+}
+
+func TestTracker_CumulativeFilledValue(t *testing.T) {
+	tracker := NewTracker()
+	order := NewOrder("HUGE", ExchangeBinance, "TEST", math.MaxUint64, 2)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if value, e := tracker.CumulativeFilledValue(order.ClientID); e != nil || value != "0" {
+		t.Errorf("Unexpected value without fills: %v, %v", value, e)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, time.Now(), math.MaxUint64, 2); e != nil {
+		t.Fatal(e)
+	}
+	if value, e := tracker.CumulativeFilledValue(order.ClientID); e != nil || value != "36893488147419103230" {
+		t.Errorf("Unexpected value exceeding uint64: %v, %v", value, e)
+	}
+	if _, e := tracker.CumulativeFilledValue("unknown"); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should not find order: %v", e)
+	}
+}
+
+func TestTracker_LastUpdateTime(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	now := start
+	tracker.now = func() time.Time { return now }
+	order := GenerateOrderWithSymbol("TEST")
+	if _, e := tracker.LastUpdateTime(order.ClientID); !errors.Is(e, ErrOrderNotFound) {
+		t.Errorf("Should return ErrOrderNotFound for unknown order: %v", e)
+	}
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.LastUpdateTime(order.ClientID); e != nil || !got.Equal(start) {
+		t.Errorf("Unexpected placing update time: %v, %v", got, e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start.Add(time.Second)); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.LastUpdateTime(order.ClientID); e != nil || !got.Equal(start.Add(time.Second)) {
+		t.Errorf("Unexpected placed update time: %v, %v", got, e)
+	}
+	now = start.Add(5 * time.Second)
+	if e := tracker.OrderMoving(order.ClientID); e != nil {
+		t.Fatal(e)
+	}
+	if got, e := tracker.LastUpdateTime(order.ClientID); e != nil || !got.Equal(now) {
+		t.Errorf("Should use local time for transitions without report: %v, %v", got, e)
+	}
+}
+
+func TestTracker_TrimHistory(t *testing.T) {
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker := NewTracker(WithFillHistory(), WithMaxFillsPerOrder(2))
+	order := NewOrder("TRIM", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	if _, e := tracker.OrderPlaceConfirmed(order.ClientID, start); e != nil {
+		t.Fatal(e)
+	}
+	for i := range 5 {
+		if _, e := tracker.OrderFilled(order.ClientID, start.Add(time.Duration(i+1)*time.Second), 1, Price(100+i)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	reportBefore, _ := tracker.GetExecutionReport(order.ClientID)
+	notionalBefore, _ := tracker.FilledNotional(order.ClientID)
+	averageBefore, _ := tracker.AverageFillPrice(order.ClientID)
+
+	if got := tracker.TrimHistory(); got != 3 {
+		t.Errorf("Unexpected number of dropped fills: %v", got)
+	}
+	if got := tracker.GetSnapshot()[0].Fills; len(got) != 2 || got[0].Price != 103 || got[1].Price != 104 {
+		t.Errorf("Should keep the most recent fills: %+v", got)
+	}
+	if got, _ := tracker.GetExecutionReport(order.ClientID); got != reportBefore {
+		t.Errorf("Should keep aggregated report: %+v != %+v", got, reportBefore)
+	}
+	if got, _ := tracker.FilledNotional(order.ClientID); got != notionalBefore {
+		t.Errorf("Should keep filled notional: %v != %v", got, notionalBefore)
+	}
+	if got, _ := tracker.AverageFillPrice(order.ClientID); got != averageBefore {
+		t.Errorf("Should keep average fill price: %v != %v", got, averageBefore)
+	}
+	if got, _ := tracker.TimeToFirstFill(order.ClientID); got != time.Second {
+		t.Errorf("Should keep time to first fill: %v", got)
+	}
+	// Placing, placed and a single entry of the partial fills keeping the status
+	history, _ := tracker.GetOrderHistory(order.ClientID)
+	if len(history) != 3 || history[2].Amount != 5 || !history[2].Time.Equal(start.Add(5*time.Second)) {
+		t.Errorf("Should not grow the history with partial fills: %+v", history)
+	}
+	if _, e := tracker.OrderFilled(order.ClientID, start.Add(time.Minute), 5, 100); e != nil {
+		t.Fatal(e)
+	}
+	if status := tracker.GetOrderStatuses([]OrderClientID{order.ClientID})[order.ClientID]; status != OrderFilled {
+		t.Errorf("Should count trimmed fills toward the order amount: %s", status)
+	}
+	if history, _ := tracker.GetOrderHistory(order.ClientID); len(history) != 4 || history[3].To != OrderFilled {
+		t.Errorf("Should record the fill completing the order: %+v", history)
+	}
+	if got := tracker.TrimHistory(); got != 1 {
+		t.Errorf("Unexpected number of dropped fills on second trim: %v", got)
+	}
+
+	if got := NewTracker().TrimHistory(); got != 0 {
+		t.Errorf("Should not trim without limit: %v", got)
+	}
+}
+
+func TestTracker_TrimHistoryReplay(t *testing.T) {
+	start := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	tracker := NewTracker(WithFillHistory(), WithMaxFillsPerOrder(2), WithEventRing(16))
+	order := NewOrder("TRIM", ExchangeBinance, "TEST", 10, 100)
+	if e := tracker.OrderPlacing(order); e != nil {
+		t.Fatal(e)
+	}
+	for i := range 4 {
+		if _, e := tracker.OrderFilled(order.ClientID, start.Add(time.Duration(i)*time.Second), 1, Price(100+i)); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if got := tracker.TrimHistory(); got != 2 {
+		t.Fatalf("Unexpected number of dropped fills: %v", got)
+	}
+
+	replayed, e := ReplayEvents(tracker.Events(), WithFillHistory())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, want := replayed.GetSnapshot(), tracker.GetSnapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Should trim replayed fills with the recorded limit:\n%+v\n!=\n%+v", got, want)
+	}
+}
+
+func TestTracker_ConcurrentStress(t *testing.T) {
+	const workers, ordersPerWorker = 8, 500
+	var rejected, filled atomic.Int64
+	tracker := NewTracker(WithEventRing(64), WithErrorLogger(func(string, OrderClientID, error) {}))
+	tracker.OnReject(func(OrderClientID, string, OrderStatus) { rejected.Add(1) })
+	fills := tracker.SubscribeFills()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range fills {
+			filled.Add(1)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			symbol := SymbolID(fmt.Sprintf("S%d", worker%3))
+			for i := range ordersPerWorker {
+				now := time.Now()
+				order := NewOrder(GenerateClientOrderID(), ExchangeBinance, symbol, 10, 100)
+				if e := tracker.OrderPlacing(order); e != nil {
+					t.Error(e)
+					return
+				}
+				switch i % 4 {
+				case 0:
+					_ = tracker.RejectPlace(order.ClientID, now, "stress")
+				case 1:
+					_, _ = tracker.OrderPlaceConfirmed(order.ClientID, now)
+					_, _ = tracker.OrderFilled(order.ClientID, now, 10, 100)
+				case 2:
+					_, _ = tracker.OrderPlaceConfirmed(order.ClientID, now)
+					_ = tracker.OrderMoving(order.ClientID)
+					_, _ = tracker.OrderMoveConfirmed(order.ClientID, now, 101)
+					_ = tracker.OrderCancelling(order.ClientID)
+					_, _ = tracker.OrderCancelConfirmed(order.ClientID, now)
+				case 3:
+					_, _ = tracker.OrderPlaceConfirmed(order.ClientID, now)
+					_, _ = tracker.OrderFilled(order.ClientID, now, 3, 100)
+				}
+				_, _ = tracker.PushQuote(ExchangeBinance, symbol, 99, 101)
+				_, _, _, _ = tracker.GetCurrentStatus(order.ClientID)
+				tracker.Inventory(ExchangeBinance, symbol)
+				if i%50 == 0 {
+					tracker.GetSnapshotForExchange(ExchangeBinance)
+					tracker.PurgeCompleted(now)
+					tracker.Clone()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	tracker.UnsubscribeFills(fills)
+	<-done
+
+	if violations := tracker.CheckInvariants(); len(violations) != 0 {
+		t.Errorf("Should stay consistent under concurrent calls: %v", violations)
+	}
+	if got, want := rejected.Load(), int64(workers*ordersPerWorker/4); got != want {
+		t.Errorf("Unexpected number of reject notifications: %v != %v", got, want)
+	}
+	if got, want := tracker.Stats().Filled, uint64(workers*ordersPerWorker/2); got != want {
+		t.Errorf("Unexpected number of fills: %v != %v", got, want)
+	}
+	// Notifications are dropped for a slow consumer
+	if got := filled.Load(); got == 0 || got > int64(workers*ordersPerWorker/2) {
+		t.Errorf("Unexpected number of fill notifications: %v", got)
+	}
+}
+
+// benchmarkOrderBurst measures placing a burst of orders into a new tracker.
+func benchmarkOrderBurst(b *testing.B, opts ...Option) {
+	const burst = 10000
+	orders := make([]Order, burst)
+	for i := range orders {
+		orders[i] = GenerateOrderWithSymbol("TEST")
+	}
+	for b.Loop() {
+		tracker := NewTracker(opts...)
+		for _, order := range orders {
+			if e := tracker.OrderPlacing(order); e != nil {
+				b.Fatal(e)
+			}
+		}
+	}
+}
+
+func BenchmarkTracker_OrderBurst(b *testing.B) {
+	benchmarkOrderBurst(b)
+}
+
+func BenchmarkTracker_OrderBurstWithInitialCapacity(b *testing.B) {
+	benchmarkOrderBurst(b, WithInitialCapacity(10000, 1))
+}
+
 func BenchmarkTracker_OrderGenerateAndPlace(b *testing.B) {
 	tracker := NewTracker()
 	wantSymbol := SymbolID("TEST")
@@ -0,0 +1,145 @@
+// SPDX-File-CopyrightText: (c) 2025 Andrei Ilin <ortfero@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package orderstracker
+
+import (
+	"fmt"
+	"slices"
+)
+
+// operation is a call or an exchange report that may change the order status.
+type operation int
+
+const (
+	opPlace operation = iota
+	opPlaceConfirm
+	opReject
+	opMove
+	opMoveConfirm
+	opCancel
+	opCancelConfirm
+	opPartialCancel
+	opExpire
+	opFill
+	opMarkUnknown
+	opResolve
+)
+
+func (op operation) String() string {
+	switch op {
+	case opPlace:
+		return "placement"
+	case opPlaceConfirm:
+		return "placement confirmation"
+	case opReject:
+		return "rejection"
+	case opMove:
+		return "modification"
+	case opMoveConfirm:
+		return "modification confirmation"
+	case opCancel:
+		return "cancellation"
+	case opCancelConfirm:
+		return "cancellation confirmation"
+	case opPartialCancel:
+		return "partial cancellation"
+	case opExpire:
+		return "expiration"
+	case opFill:
+		return "fill"
+	case opMarkUnknown:
+		return "marking unknown"
+	case opResolve:
+		return "resolution"
+	default:
+		return "none"
+	}
+}
+
+// transitions lists for each status the operations accepted in it and the statuses
+// they may move the order into. An operation keeping the order status lists the status itself.
+// Every method changing the order status checks the change against this table.
+// A fill completing the order may arrive in any status but OrderFilled,
+// including orders canceled, rejected or expired while the fill was in flight.
+// Fills of filled orders exceed the order amount and are rejected with ErrOverfill.
+// A modification confirmed while the order is canceling keeps the order canceling.
+// WithStrictFills removes the fills of orders in OrderUnplaced and OrderPlacing.
+var transitions = [...]map[operation][]OrderStatus{
+	OrderUnplaced: {
+		opPlace: {OrderPlacing},
+		opFill:  {OrderUnplaced, OrderFilled},
+	},
+	OrderPlacing: {
+		opPlaceConfirm: {OrderPlaced},
+		opReject:       {OrderUnplaced},
+		opFill:         {OrderPlacing, OrderFilled},
+		opMarkUnknown:  {OrderUnknown},
+	},
+	OrderPlaced: {
+		opMove:          {OrderModifying},
+		opCancel:        {OrderCanceling},
+		opPartialCancel: {OrderPlaced, OrderUnplaced},
+		opFill:          {OrderPlaced, OrderFilled},
+		opExpire:        {OrderExpired},
+		opMarkUnknown:   {OrderUnknown},
+	},
+	OrderModifying: {
+		opMoveConfirm: {OrderPlaced},
+		opReject:      {OrderPlaced},
+		opCancel:      {OrderCanceling},
+		opFill:        {OrderModifying, OrderFilled},
+		opExpire:      {OrderExpired},
+		opMarkUnknown: {OrderUnknown},
+	},
+	OrderCanceling: {
+		opCancelConfirm: {OrderUnplaced},
+		opReject:        {OrderPlaced, OrderModifying},
+		opMoveConfirm:   {OrderCanceling},
+		opPartialCancel: {OrderPlaced, OrderUnplaced},
+		opFill:          {OrderCanceling, OrderFilled},
+		opExpire:        {OrderExpired},
+		opMarkUnknown:   {OrderUnknown},
+	},
+	OrderFilled: {},
+	OrderExpired: {
+		opPlace: {OrderPlacing},
+		opFill:  {OrderExpired, OrderFilled},
+	},
+	OrderUnknown: {
+		opResolve: {OrderPlaced, OrderUnplaced, OrderFilled, OrderExpired},
+		opFill:    {OrderUnknown, OrderFilled},
+	},
+}
+
+// CanTransition reports whether an order may change its status from one status into another.
+// It returns false if the statuses are the same or any of them is not defined.
+func CanTransition(from, to OrderStatus) bool {
+	if !from.isValid() || !to.isValid() || from == to {
+		return false
+	}
+	for _, targets := range transitions[from] {
+		if slices.Contains(targets, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// accepts reports whether the operation is accepted in the status.
+func accepts(from OrderStatus, op operation) bool {
+	return from.isValid() && transitions[from][op] != nil
+}
+
+// checkTransition returns an error if the operation can't move the order from its status into to.
+func checkTransition(c *orderContext, op operation, to OrderStatus) error {
+	from := c.Status
+	if !accepts(from, op) {
+		return fmt.Errorf("%w: %s of order in status '%s' (clid %v)", ErrInvalidTransition, op, from, c.Order.ClientID)
+	}
+	if !slices.Contains(transitions[from][op], to) {
+		return fmt.Errorf("%w: %s of order from '%s' to '%s' (clid %v)", ErrInvalidTransition, op, from, to,
+			c.Order.ClientID)
+	}
+	return nil
+}
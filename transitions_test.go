@@ -0,0 +1,264 @@
+package orderstracker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_CanTransition(t *testing.T) {
+	if !CanTransition(OrderPlacing, OrderPlaced) {
+		t.Error("Should allow placement confirmation")
+	}
+	if CanTransition(OrderFilled, OrderPlacing) {
+		t.Error("Should not allow leaving OrderFilled")
+	}
+	if CanTransition(OrderPlaced, OrderPlaced) {
+		t.Error("Should not report the same status as a transition")
+	}
+	if CanTransition(OrderStatus(-1), OrderPlacing) || CanTransition(OrderPlaced, OrderUnknown+1) {
+		t.Error("Should not allow undefined statuses")
+	}
+}
+
+// transitionSetup is a sequence of calls driving the order into a status,
+// pending is set if a modification is pending while the order is canceling.
+type transitionSetup struct {
+	from    OrderStatus
+	pending bool
+	steps   []func(*Tracker) error
+}
+
+// transitionSetups returns the setups driving the order into every status.
+func transitionSetups(order Order, now time.Time) []transitionSetup {
+	place := func(tracker *Tracker) error { return tracker.OrderPlacing(order) }
+	confirm := func(tracker *Tracker) error { _, e := tracker.OrderPlaceConfirmed(order.ClientID, now); return e }
+	moving := func(tracker *Tracker) error { return tracker.OrderMoving(order.ClientID) }
+	cancelling := func(tracker *Tracker) error { return tracker.OrderCancelling(order.ClientID) }
+	return []transitionSetup{
+		{OrderUnplaced, false, []func(*Tracker) error{place, func(tracker *Tracker) error {
+			return tracker.RejectPlace(order.ClientID, now, "")
+		}}},
+		{OrderPlacing, false, []func(*Tracker) error{place}},
+		{OrderPlaced, false, []func(*Tracker) error{place, confirm}},
+		{OrderModifying, false, []func(*Tracker) error{place, confirm, moving}},
+		{OrderCanceling, false, []func(*Tracker) error{place, confirm, cancelling}},
+		{OrderCanceling, true, []func(*Tracker) error{place, confirm, moving, cancelling}},
+		{OrderFilled, false, []func(*Tracker) error{place, confirm, func(tracker *Tracker) error {
+			_, e := tracker.OrderFilled(order.ClientID, now, order.Amount, order.Price)
+			return e
+		}}},
+		{OrderExpired, false, []func(*Tracker) error{place, confirm, func(tracker *Tracker) error {
+			_, e := tracker.OrderExpired(order.ClientID, now)
+			return e
+		}}},
+		{OrderUnknown, false, []func(*Tracker) error{place, confirm, func(tracker *Tracker) error {
+			_, e := tracker.MarkOrderUnknown(order.ClientID, now)
+			return e
+		}}},
+	}
+}
+
+// newTransitionTracker returns a tracker with the order driven into the status of the setup.
+func newTransitionTracker(t *testing.T, setup transitionSetup) *Tracker {
+	tracker := NewTracker()
+	for _, step := range setup.steps {
+		if e := step(tracker); e != nil {
+			t.Fatal(e)
+		}
+	}
+	return tracker
+}
+
+// Test_CanTransitionMatchesTracker drives an order into every status, applies every mutating
+// call and checks that the observed status changes are exactly the ones of the transition table.
+func Test_CanTransitionMatchesTracker(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	order := NewOrder("ORDER", ExchangeBinance, "TEST", 10, 100)
+	mutations := map[string]func(*Tracker){
+		"OrderPlacing":        func(tracker *Tracker) { _ = tracker.OrderPlacing(order) },
+		"OrderPlaceConfirmed": func(tracker *Tracker) { _, _ = tracker.OrderPlaceConfirmed(order.ClientID, now) },
+		"OrderRejected":       func(tracker *Tracker) { _, _ = tracker.OrderRejected(order.ClientID, now, "") },
+		"RejectPlace":         func(tracker *Tracker) { _ = tracker.RejectPlace(order.ClientID, now, "") },
+		"RejectModify":        func(tracker *Tracker) { _ = tracker.RejectModify(order.ClientID, now, "") },
+		"RejectCancel":        func(tracker *Tracker) { _ = tracker.RejectCancel(order.ClientID, now, "") },
+		"OrderMoving":         func(tracker *Tracker) { _ = tracker.OrderMoving(order.ClientID) },
+		"OrderMoveConfirmed":  func(tracker *Tracker) { _, _ = tracker.OrderMoveConfirmed(order.ClientID, now, 101) },
+		"OrderCancelling":     func(tracker *Tracker) { _ = tracker.OrderCancelling(order.ClientID) },
+		"CancelAll":           func(tracker *Tracker) { tracker.CancelAll() },
+		"CancelSymbol":        func(tracker *Tracker) { tracker.CancelSymbol(order.Exchange, order.Symbol) },
+		"CancelByTag":         func(tracker *Tracker) { tracker.CancelByTag(order.Tag) },
+		"OrderCancelConfirmed": func(tracker *Tracker) {
+			_, _ = tracker.OrderCancelConfirmed(order.ClientID, now)
+		},
+		"OrderPartialCancelConfirmed": func(tracker *Tracker) {
+			_, _ = tracker.OrderPartialCancelConfirmed(order.ClientID, now, 1)
+		},
+		"OrderPartialCancelConfirmedRemaining": func(tracker *Tracker) {
+			_, _ = tracker.OrderPartialCancelConfirmed(order.ClientID, now, order.Amount)
+		},
+		"OrderExpired":       func(tracker *Tracker) { _, _ = tracker.OrderExpired(order.ClientID, now) },
+		"OrderFilled":        func(tracker *Tracker) { _, _ = tracker.OrderFilled(order.ClientID, now, order.Amount, 100) },
+		"OrderFilledPartial": func(tracker *Tracker) { _, _ = tracker.OrderFilled(order.ClientID, now, 1, 100) },
+		"MarkOrderUnknown":   func(tracker *Tracker) { _, _ = tracker.MarkOrderUnknown(order.ClientID, now) },
+	}
+	for status := OrderUnplaced; status <= OrderUnknown; status++ {
+		mutations["ResolveUnknown"+status.String()] = func(tracker *Tracker) {
+			_ = tracker.ResolveUnknown(order.ClientID, now, status)
+		}
+	}
+
+	observed := make(map[[2]OrderStatus]bool)
+	for _, setup := range transitionSetups(order, now) {
+		from := setup.from
+		for name, mutation := range mutations {
+			tracker := newTransitionTracker(t, setup)
+			mutation(tracker)
+			var gotOrder Order
+			var gotReport ExecutionReport
+			to, e := tracker.GetOrderStatus(order.ClientID, &gotOrder, &gotReport)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if to == from {
+				continue
+			}
+			if !CanTransition(from, to) {
+				t.Errorf("%s moved order from '%s' to '%s' not allowed by the table", name, from, to)
+			}
+			observed[[2]OrderStatus{from, to}] = true
+		}
+	}
+	for from := OrderUnplaced; from <= OrderUnknown; from++ {
+		for to := OrderUnplaced; to <= OrderUnknown; to++ {
+			if CanTransition(from, to) && !observed[[2]OrderStatus{from, to}] {
+				t.Errorf("Transition from '%s' to '%s' is not performed by any call", from, to)
+			}
+		}
+	}
+}
+
+// Test_TransitionsTable walks the transition table: every public method performing an operation
+// succeeds in a status exactly if the table has the edge of the operation into the expected status,
+// moves the order into that status and fails with ErrInvalidTransition otherwise.
+func Test_TransitionsTable(t *testing.T) {
+	now := time.Date(2025, 4, 12, 10, 0, 0, 0, time.UTC)
+	// Confirmations older than the setup ones are never taken for duplicate acknowledgments
+	earlier := now.Add(-time.Second)
+	order := NewOrder("ORDER", ExchangeBinance, "TEST", 10, 100)
+	same := func(from OrderStatus, _ bool) OrderStatus { return from }
+	into := func(to OrderStatus) func(OrderStatus, bool) OrderStatus {
+		return func(OrderStatus, bool) OrderStatus { return to }
+	}
+	calls := []struct {
+		name string
+		op   operation
+		to   func(from OrderStatus, pending bool) OrderStatus
+		call func(*Tracker) error
+	}{
+		{"OrderPlacing", opPlace, into(OrderPlacing), func(tracker *Tracker) error {
+			return tracker.OrderPlacing(order)
+		}},
+		{"OrderPlaceConfirmed", opPlaceConfirm, into(OrderPlaced), func(tracker *Tracker) error {
+			_, e := tracker.OrderPlaceConfirmed(order.ClientID, earlier)
+			return e
+		}},
+		{"OrderRejected", opReject, func(from OrderStatus, pending bool) OrderStatus {
+			switch {
+			case from == OrderPlacing:
+				return OrderUnplaced
+			case pending:
+				return OrderModifying
+			default:
+				return OrderPlaced
+			}
+		}, func(tracker *Tracker) error {
+			_, e := tracker.OrderRejected(order.ClientID, now, "")
+			return e
+		}},
+		{"OrderMoving", opMove, into(OrderModifying), func(tracker *Tracker) error {
+			return tracker.OrderMoving(order.ClientID)
+		}},
+		{"OrderMoveConfirmed", opMoveConfirm, func(from OrderStatus, pending bool) OrderStatus {
+			if from == OrderCanceling && pending {
+				return OrderCanceling
+			}
+			return OrderPlaced
+		}, func(tracker *Tracker) error {
+			_, e := tracker.OrderMoveConfirmed(order.ClientID, earlier, 101)
+			return e
+		}},
+		{"OrderCancelling", opCancel, into(OrderCanceling), func(tracker *Tracker) error {
+			return tracker.OrderCancelling(order.ClientID)
+		}},
+		{"OrderCancelConfirmed", opCancelConfirm, into(OrderUnplaced), func(tracker *Tracker) error {
+			_, e := tracker.OrderCancelConfirmed(order.ClientID, earlier)
+			return e
+		}},
+		{"OrderPartialCancelConfirmed", opPartialCancel, into(OrderPlaced), func(tracker *Tracker) error {
+			_, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, 1)
+			return e
+		}},
+		{"OrderPartialCancelConfirmedRemaining", opPartialCancel, into(OrderUnplaced), func(tracker *Tracker) error {
+			_, e := tracker.OrderPartialCancelConfirmed(order.ClientID, now, order.Amount)
+			return e
+		}},
+		{"OrderExpired", opExpire, into(OrderExpired), func(tracker *Tracker) error {
+			_, e := tracker.OrderExpired(order.ClientID, now)
+			return e
+		}},
+		{"OrderFilled", opFill, into(OrderFilled), func(tracker *Tracker) error {
+			_, e := tracker.OrderFilled(order.ClientID, now, order.Amount, 100)
+			return e
+		}},
+		{"OrderFilledPartial", opFill, same, func(tracker *Tracker) error {
+			_, e := tracker.OrderFilled(order.ClientID, now, 1, 100)
+			return e
+		}},
+		{"MarkOrderUnknown", opMarkUnknown, into(OrderUnknown), func(tracker *Tracker) error {
+			_, e := tracker.MarkOrderUnknown(order.ClientID, now)
+			return e
+		}},
+	}
+	for status := OrderUnplaced; status <= OrderUnknown; status++ {
+		calls = append(calls, struct {
+			name string
+			op   operation
+			to   func(from OrderStatus, pending bool) OrderStatus
+			call func(*Tracker) error
+		}{"ResolveUnknown" + status.String(), opResolve, into(status), func(tracker *Tracker) error {
+			return tracker.ResolveUnknown(order.ClientID, now, status)
+		}})
+	}
+
+	for _, setup := range transitionSetups(order, now) {
+		for _, c := range calls {
+			tracker := newTransitionTracker(t, setup)
+			to := c.to(setup.from, setup.pending)
+			// Any fill of a filled order exceeds its amount
+			if setup.from == OrderFilled && c.op == opFill {
+				if e := c.call(tracker); !errors.Is(e, ErrOverfill) {
+					t.Errorf("%s should fail from '%s' with ErrOverfill: %v", c.name, setup.from, e)
+				}
+				continue
+			}
+			allowed := slices.Contains(transitions[setup.from][c.op], to)
+			if c.op == opMoveConfirm && setup.from == OrderCanceling && !setup.pending {
+				allowed = false
+			}
+			e := c.call(tracker)
+			switch {
+			case allowed && e != nil:
+				t.Errorf("%s should be allowed from '%s' (pending %v): %v", c.name, setup.from, setup.pending, e)
+			case !allowed && !errors.Is(e, ErrInvalidTransition):
+				t.Errorf("%s should fail from '%s' (pending %v) with ErrInvalidTransition: %v",
+					c.name, setup.from, setup.pending, e)
+			case allowed:
+				if got := tracker.GetOrderStatuses([]OrderClientID{order.ClientID})[order.ClientID]; got != to {
+					t.Errorf("%s should move order from '%s' to '%s': %s", c.name, setup.from, to, got)
+				}
+			}
+		}
+	}
+}